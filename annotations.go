@@ -0,0 +1,160 @@
+package main
+
+import (
+	"go/token"
+	"log"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gorm.io/gen"
+)
+
+// annotations are the generator directives found in a table or column comment:
+//
+//	@gotype:github.com/shopspring/decimal.Decimal  Go type of the field (column)
+//	@json:-                                        json tag of the field (column)
+//	@name:ExternalRef                              name of the field or struct
+//	@readonly                                      read-only field, or all fields of the table
+type annotations struct {
+	GoType   string // type as written in the generated code, e.g. decimal.Decimal
+	Import   string // import spec of the package GoType comes from, if given with its import path
+	JSON     string
+	Name     string
+	ReadOnly bool
+}
+
+var (
+	annotationRegexp = regexp.MustCompile(`(^|\s)@([A-Za-z_]\w*)(:(\S*))?`)
+	goTypeRegexp     = regexp.MustCompile(`^((\*|\[\])*)((([\w.~-]+/)*[\w.~-]+)\.)?([A-Za-z_]\w*)$`)
+	majorVersion     = regexp.MustCompile(`^v[0-9]+$`)
+	gopkgVersion     = regexp.MustCompile(`\.v[0-9]+$`)
+)
+
+// parseAnnotations extracts the annotations from comment and returns the comment without them.
+// Malformed, unknown or misplaced annotations are reported as warnings naming where (e.g.
+// "column users.email") and otherwise ignored. Table comments accept only @name and @readonly.
+func parseAnnotations(comment, where string, table bool) (string, annotations) {
+	var ann annotations
+	seen := map[string]bool{}
+	warn := func(format string, args ...any) {
+		log.Printf("warning: %s: "+format, append([]any{where}, args...)...)
+	}
+	for _, m := range annotationRegexp.FindAllStringSubmatch(comment, -1) {
+		key, hasValue, value := m[2], m[3] != "", m[4]
+		if seen[key] {
+			warn("@%s is given more than once, the last one is used", key)
+		}
+		seen[key] = true
+		switch key {
+		case "gotype", "json", "name":
+			if value == "" {
+				warn("@%s needs a value, as in @%s:<value>", key, key)
+				continue
+			}
+		case "readonly":
+			if hasValue {
+				warn("@readonly takes no value, ignoring %q", value)
+			}
+		default:
+			warn("unknown annotation @%s (expected @gotype, @json, @name or @readonly)", key)
+			continue
+		}
+		if table && (key == "gotype" || key == "json") {
+			warn("@%s applies to columns, not tables", key)
+			continue
+		}
+		switch key {
+		case "gotype":
+			goType, importSpec, ok := parseGoTypeAnnotation(value)
+			if !ok {
+				warn("malformed @gotype:%s (expected a type such as string, decimal.Decimal or github.com/shopspring/decimal.Decimal)", value)
+				continue
+			}
+			ann.GoType, ann.Import = goType, importSpec
+		case "json":
+			ann.JSON = value
+		case "name":
+			if !token.IsIdentifier(value) || !token.IsExported(value) {
+				warn("malformed @name:%s (expected an exported Go identifier)", value)
+				continue
+			}
+			ann.Name = value
+		case "readonly":
+			ann.ReadOnly = true
+		}
+	}
+	return stripAnnotations(comment), ann
+}
+
+// parseGoTypeAnnotation splits a @gotype value into the type used in generated code and the
+// import spec of its package: *github.com/org/pkg/v2.Type becomes *pkg.Type and
+// "github.com/org/pkg/v2". The package is imported under an explicit name when that name cannot
+// be read off the last path element (go-money -> money "github.com/org/go-money").
+func parseGoTypeAnnotation(value string) (goType, importSpec string, ok bool) {
+	m := goTypeRegexp.FindStringSubmatch(value)
+	if m == nil {
+		return "", "", false
+	}
+	prefix, pkg, name := m[1], m[4], m[6]
+	if !strings.Contains(pkg, "/") {
+		return value, "", true
+	}
+	pkgName := path.Base(pkg)
+	if majorVersion.MatchString(pkgName) {
+		pkgName = path.Base(path.Dir(pkg))
+	}
+	pkgName = gopkgVersion.ReplaceAllString(strings.TrimPrefix(pkgName, "go-"), "")
+	pkgName = strings.NewReplacer("-", "", ".", "").Replace(pkgName)
+	importSpec = strconv.Quote(pkg)
+	if pkgName != path.Base(pkg) {
+		importSpec = pkgName + " " + importSpec
+	}
+	return prefix + pkgName + "." + name, importSpec, true
+}
+
+// stripAnnotations removes annotations from a comment, dropping lines left empty.
+func stripAnnotations(comment string) string {
+	lines := strings.Split(comment, "\n")
+	out := lines[:0]
+	for _, line := range lines {
+		stripped := strings.TrimSpace(annotationRegexp.ReplaceAllString(line, "$1"))
+		if stripped != "" || strings.TrimSpace(line) == "" {
+			out = append(out, stripped)
+		}
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+// applyColumnAnnotations applies the annotations of a table's column comments to its fields and
+// returns the import specs the annotated types need. readOnly marks every column field read-only.
+func applyColumnAnnotations(fields []gen.Field, columns map[string]annotations, readOnly bool) (imports []string) {
+	for _, f := range fields {
+		if f.ColumnName == "" {
+			continue
+		}
+		ann := columns[f.ColumnName]
+		if ann.GoType != "" {
+			goType := ann.GoType
+			// Keep the pointer gen adds for nullable columns, as for TypeMap types.
+			if strings.HasPrefix(f.Type, "*") && !strings.HasPrefix(goType, "*") && !strings.HasPrefix(goType, "[]") {
+				goType = "*" + goType
+			}
+			f.Type = goType
+			if ann.Import != "" {
+				imports = append(imports, ann.Import)
+			}
+		}
+		if ann.JSON != "" {
+			f.Tag.Set("json", ann.JSON)
+		}
+		if ann.Name != "" {
+			f.Name = ann.Name
+		}
+		if ann.ReadOnly || readOnly {
+			f.GORMTag.Set("->")
+		}
+	}
+	return imports
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	return &buf
+}
+
+func TestParseAnnotations(t *testing.T) {
+	logs := captureLog(t)
+	text, ann := parseAnnotations("Price in cents. @gotype:github.com/shopspring/decimal.Decimal @json:-\n@readonly\nContact ops@example.com", "column items.price", false)
+	if text != "Price in cents.\nContact ops@example.com" {
+		t.Errorf("comment text %q", text)
+	}
+	want := annotations{GoType: "decimal.Decimal", Import: `"github.com/shopspring/decimal"`, JSON: "-", ReadOnly: true}
+	if ann != want {
+		t.Errorf("annotations %+v", ann)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("unexpected warnings: %s", logs)
+	}
+
+	_, ann = parseAnnotations("@name:ExternalRef @readonly", "table refs", true)
+	if ann.Name != "ExternalRef" || !ann.ReadOnly {
+		t.Errorf("table annotations %+v", ann)
+	}
+
+	for comment, warning := range map[string]string{
+		"@gotype:":             "@gotype needs a value",
+		"@gotype:map[string]x": "malformed @gotype:map[string]x",
+		"@name:externalRef":    "malformed @name:externalRef",
+		"@readonly:yes":        "@readonly takes no value",
+		"@jsn:-":               "unknown annotation @jsn",
+		"@json:a @json:b":      "@json is given more than once",
+	} {
+		logs.Reset()
+		parseAnnotations(comment, "column t.c", false)
+		if !strings.Contains(logs.String(), "warning: column t.c: "+warning) {
+			t.Errorf("%q: got warnings %q, want %q", comment, logs.String(), warning)
+		}
+	}
+	logs.Reset()
+	if _, ann := parseAnnotations("@gotype:string @json:x", "table t", true); ann.GoType != "" || ann.JSON != "" {
+		t.Errorf("column annotations applied to a table: %+v", ann)
+	}
+	if !strings.Contains(logs.String(), "@gotype applies to columns, not tables") {
+		t.Errorf("got warnings %q", logs.String())
+	}
+}
+
+func TestParseGoTypeAnnotation(t *testing.T) {
+	for value, want := range map[string][2]string{
+		"string":                                 {"string", ""},
+		"decimal.Decimal":                        {"decimal.Decimal", ""},
+		"*github.com/shopspring/decimal.Decimal": {"*decimal.Decimal", `"github.com/shopspring/decimal"`},
+		"[]github.com/google/uuid.UUID":          {"[]uuid.UUID", `"github.com/google/uuid"`},
+		"github.com/jackc/pgx/v5/pgtype.Numeric": {"pgtype.Numeric", `"github.com/jackc/pgx/v5/pgtype"`},
+		"github.com/org/go-money/v2.Money":       {"money.Money", `money "github.com/org/go-money/v2"`},
+		"gopkg.in/guregu/null.v4.String":         {"null.String", `null "gopkg.in/guregu/null.v4"`},
+	} {
+		goType, importSpec, ok := parseGoTypeAnnotation(value)
+		if !ok || goType != want[0] || importSpec != want[1] {
+			t.Errorf("%s: got %s, %s, %v", value, goType, importSpec, ok)
+		}
+	}
+}
+
+func TestApplyColumnAnnotations(t *testing.T) {
+	newField := func(name, column, goType string) gen.Field {
+		f := gen.FieldNew("", "", nil)(nil)
+		f.Name, f.ColumnName, f.Type = name, column, goType
+		f.Tag = field.Tag{}
+		f.GORMTag = field.GormTag{}
+		return f
+	}
+	fields := []gen.Field{newField("Price", "price", "*float64"), newField("Ref", "ref", "string"), newField("Owner", "", "*User")}
+	imports := applyColumnAnnotations(fields, map[string]annotations{
+		"price": {GoType: "decimal.Decimal", Import: `"github.com/shopspring/decimal"`, JSON: "-"},
+		"ref":   {Name: "ExternalRef"},
+	}, true)
+	if fields[0].Type != "*decimal.Decimal" || fields[0].Tag["json"] != "-" || fields[1].Name != "ExternalRef" {
+		t.Errorf("fields %+v %+v", fields[0], fields[1])
+	}
+	if _, ok := fields[1].GORMTag["->"]; !ok || fields[2].GORMTag["->"] != nil {
+		t.Errorf("read-only tags %v %v", fields[1].GORMTag, fields[2].GORMTag)
+	}
+	if len(imports) != 1 || imports[0] != `"github.com/shopspring/decimal"` {
+		t.Errorf("imports %v", imports)
+	}
+}
+
+func TestApplyModelNameAnnotations(t *testing.T) {
+	logs := captureLog(t)
+	names := map[pgTable]string{
+		{Schema: "public", Name: "ext_refs"}: "ExtRef",
+		{Schema: "public", Name: "users"}:    "User",
+		{Schema: "public", Name: "people"}:   "Person",
+	}
+	applyModelNameAnnotations(names, map[string]annotations{"ext_refs": {Name: "ExternalRef"}, "people": {Name: "User"}})
+	if names[pgTable{Schema: "public", Name: "ext_refs"}] != "ExternalRef" || names[pgTable{Schema: "public", Name: "people"}] != "Person" {
+		t.Errorf("names %v", names)
+	}
+	if !strings.Contains(logs.String(), "warning: table people: @name:User is already the name of another model") {
+		t.Errorf("got warnings %q", logs.String())
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"golang.org/x/tools/imports"
+	"gorm.io/gorm/schema"
+)
+
+// writeModelsFile renders tmpl with data into a file in the models package, next to the gen
+// output. Unused imports are dropped, so templates may list every package a field type could
+// need. fileName should end in .gen.go so that CleanUp removes it on the next run.
+func writeModelsFile(outPath, fileName, tmpl string, data any) {
+	writeGenFile(filepath.Join(outPath, "models"), fileName, tmpl, data)
+}
+
+// writeGenFile renders tmpl with data into dir/fileName, as writeModelsFile does for the models
+// package; the query package is outPath itself.
+func writeGenFile(dir, fileName, tmpl string, data any) {
+	t, err := template.New(fileName).Parse(tmpl)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		log.Fatal(err)
+	}
+	src, err := imports.Process(filepath.Join(dir, fileName), buf.Bytes(), nil)
+	if err != nil {
+		log.Fatalf("format %s: %v", fileName, err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, fileName), src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// modelImportPaths lists the packages generated model code may reference: those of the default
+// type maps plus the configured ImportPackagePaths.
+func modelImportPaths(cfg ConversionConfig) []string {
+	return append([]string{
+		"time",
+		"gorm.io/datatypes",
+		"github.com/dan-sherwin/gormdb2struct/pgtypes",
+	}, cfg.ImportPackagePaths...)
+}
+
+// goFieldName names a struct field for a column the way gen does (user_id -> UserID).
+func goFieldName(column string) string {
+	return schema.NamingStrategy{SingularTable: true}.SchemaName(column)
+}
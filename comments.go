@@ -0,0 +1,86 @@
+package main
+
+import (
+	"strings"
+
+	"gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+// columnDocComments sets the comment of every column field to the column's database comment
+// followed by its type, nullability and default, e.g. "Login e-mail address.\ntext NOT NULL".
+// comments and summaries, when given, replace the comments and descriptions derived from the
+// driver's column types, which carry no comments and unreliable nullability for SQLite.
+// Single-line comments are rendered after the field, longer ones as a block above it.
+func columnDocComments(columnTypes []gorm.ColumnType, fields []gen.Field, comments, summaries map[string]string) {
+	byName := make(map[string]gorm.ColumnType, len(columnTypes))
+	for _, ct := range columnTypes {
+		byName[ct.Name()] = ct
+	}
+	for _, f := range fields {
+		ct, ok := byName[f.ColumnName]
+		if f.ColumnName == "" || !ok {
+			continue
+		}
+		comment := comments[f.ColumnName]
+		if comments == nil {
+			comment, _ = ct.Comment()
+		}
+		summary, ok := summaries[f.ColumnName]
+		if !ok {
+			summary = columnSummary(ct)
+		}
+		lines := append(commentLines(comment), summary)
+		f.ColumnComment = strings.ReplaceAll(strings.Join(lines, "\n"), "*/", "* /")
+		f.MultilineComment = len(lines) > 1
+	}
+}
+
+// columnSummary describes a column the way it would be declared: type, NULL or NOT NULL, and
+// DEFAULT when the column has one.
+func columnSummary(ct gorm.ColumnType) string {
+	typ, ok := ct.ColumnType()
+	if !ok || typ == "" {
+		typ = ct.DatabaseTypeName()
+	}
+	nullable, known := ct.Nullable()
+	def, _ := ct.DefaultValue()
+	return formatColumnSummary(typ, nullable, known, def)
+}
+
+func formatColumnSummary(typ string, nullable, nullableKnown bool, def string) string {
+	parts := []string{typ}
+	if nullableKnown {
+		if nullable {
+			parts = append(parts, "NULL")
+		} else {
+			parts = append(parts, "NOT NULL")
+		}
+	}
+	if def != "" {
+		parts = append(parts, "DEFAULT "+def)
+	}
+	return strings.Join(parts, " ")
+}
+
+// tableDocComment turns a table comment into the text gen puts after the struct name in the
+// model's doc comment.
+func tableDocComment(table, comment string) string {
+	lines := commentLines(comment)
+	if len(lines) == 0 {
+		return ""
+	}
+	return "mapped from table <" + table + ">\n//\n// " + strings.Join(lines, "\n// ")
+}
+
+// commentLines splits a database comment into trimmed lines without leading or trailing blanks.
+func commentLines(comment string) []string {
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(comment, "\r\n", "\n")), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return nil
+	}
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	return lines
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+
+	"gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+var decimalSizeRegexp = regexp.MustCompile(`(?i)^\s*(?:numeric|decimal)\s*\(\s*(\d+)\s*(?:,\s*(-?\d+)\s*)?\)`)
+
+// decimalSize returns the precision and scale of a declared numeric or decimal type such as
+// numeric(12,2) or DECIMAL(10). Arrays (numeric(12,2)[]) report the size of their elements.
+func decimalSize(declared string) (precision, scale int64, ok bool) {
+	m := decimalSizeRegexp.FindStringSubmatch(declared)
+	if m == nil {
+		return 0, 0, false
+	}
+	precision, _ = strconv.ParseInt(m[1], 10, 64)
+	if m[2] != "" {
+		scale, _ = strconv.ParseInt(m[2], 10, 64)
+	}
+	return precision, scale, true
+}
+
+// addDecimalSizeTags adds precision and scale to the gorm tag of every numeric or decimal column
+// declared with a precision. declared, when given, maps column names to their declared types and
+// replaces the types reported by the driver's column types.
+func addDecimalSizeTags(columnTypes []gorm.ColumnType, fields []gen.Field, declared map[string]string) {
+	if declared == nil {
+		declared = make(map[string]string, len(columnTypes))
+		for _, ct := range columnTypes {
+			declared[ct.Name()], _ = ct.ColumnType()
+		}
+	}
+	for _, f := range fields {
+		precision, scale, ok := decimalSize(declared[f.ColumnName])
+		if f.ColumnName == "" || !ok {
+			continue
+		}
+		f.GORMTag.Set("precision", strconv.FormatInt(precision, 10))
+		f.GORMTag.Set("scale", strconv.FormatInt(scale, 10))
+	}
+}
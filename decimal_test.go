@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestDecimalSize(t *testing.T) {
+	for _, c := range []struct {
+		declared         string
+		precision, scale int64
+		ok               bool
+	}{
+		{"numeric(12,2)", 12, 2, true},
+		{"NUMERIC(12, 2)", 12, 2, true},
+		{"DECIMAL(10)", 10, 0, true},
+		{"numeric(12,2)[]", 12, 2, true},
+		{"numeric", 0, 0, false},
+		{"integer", 0, 0, false},
+		{"character varying(255)", 0, 0, false},
+	} {
+		p, s, ok := decimalSize(c.declared)
+		if p != c.precision || s != c.scale || ok != c.ok {
+			t.Fatalf("decimalSize(%q) = %d, %d, %v", c.declared, p, s, ok)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dan-sherwin/gormdb2struct/pgtypes"
+)
+
+// TestPgEnumsNoDB renders the Go types for PostgreSQL enums without a database and runs a
+// small program against them to check Scan/Value validation, JSON and the array form.
+func TestPgEnumsNoDB(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping generated code test in short mode")
+	}
+	outPath := generatedTypesDir(t, "generated_pg_enums")
+	enums := pgEnums{
+		"ticket_status": {pgUserType: pgUserType{Name: "ticket_status", SQLName: "ticket_status", TypeName: "TicketStatus", Used: true, UsedArr: true},
+			Labels: []string{"open", "in-progress", "closed"}},
+		"unused_enum": {pgUserType: pgUserType{Name: "unused_enum", SQLName: "unused_enum", TypeName: "UnusedEnum"},
+			Labels: []string{"x"}},
+	}
+	generatePgEnums(ConversionConfig{OutPath: outPath}, enums)
+
+	b, err := os.ReadFile(filepath.Join(outPath, "models", "enums.gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustContain(t, string(b), "TicketStatusInProgress TicketStatus = \"in-progress\"")
+	if strings.Contains(string(b), "UnusedEnum") {
+		t.Fatal("enums not referenced by a column should not be generated")
+	}
+
+	runGeneratedTypesProgram(t, outPath, `
+	var s m.TicketStatus
+	must(s.Scan([]byte("in-progress")))
+	if s != m.TicketStatusInProgress || len(s.Values()) != 3 { panic(s) }
+	if err := s.Scan("bogus"); err == nil { panic("unknown label accepted by Scan") }
+	if _, err := m.TicketStatus("bogus").Value(); err == nil { panic("unknown label accepted by Value") }
+	js, _ := json.Marshal(m.TicketStatusClosed)
+	if string(js) != `+"`"+`"closed"`+"`"+` { panic(string(js)) }
+	if err := json.Unmarshal([]byte(`+"`"+`"nope"`+"`"+`), &s); err == nil { panic("unknown label accepted by JSON") }
+	js, err := json.Marshal(struct{ S m.TicketStatus }{})
+	must(err)
+	if string(js) != `+"`"+`{"S":""}`+"`"+` { panic(string(js)) }
+	z := struct{ S m.TicketStatus }{S: m.TicketStatusOpen}
+	must(json.Unmarshal(js, &z))
+	if z.S != "" { panic(z.S) }
+	if _, err := json.Marshal(m.TicketStatus("bogus")); err == nil { panic("unknown label accepted by MarshalJSON") }
+	var a m.TicketStatusArray
+	must(a.Scan("{open,closed}"))
+	if len(a) != 2 || a[1] != m.TicketStatusClosed { panic(a) }
+	if err := a.Scan("{open,nope}"); err == nil { panic("unknown label accepted in array") }
+	v, err := a.Value()
+	must(err)
+	if v != `+"`"+`{"open","closed"}`+"`"+` { panic(v) }
+`, "encoding/json")
+}
+
+// generatedTypesDir returns an output directory under the project root, so that the generated
+// models package belongs to this module, and removes it when the test ends.
+func generatedTypesDir(t *testing.T, name string) string {
+	t.Helper()
+	outPath := filepath.Join(projectRoot(t), name)
+	if err := os.MkdirAll(outPath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(outPath) })
+	return outPath
+}
+
+// runGeneratedTypesProgram builds and runs body as the main function of a program importing the
+// generated models package as m. A must(err) helper is available to body.
+func runGeneratedTypesProgram(t *testing.T, outPath, body string, imports ...string) {
+	t.Helper()
+	cmdDir := filepath.Join(outPath, "cmd")
+	if err := os.MkdirAll(cmdDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	importLines := ""
+	for _, imp := range imports {
+		importLines += fmt.Sprintf("\t%q\n", imp)
+	}
+	mainGo := fmt.Sprintf(`package main
+
+import (
+%s	m "%s/%s/models"
+)
+
+func main() {
+%s
+	fmt.Print("OK")
+}
+
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+`, importLines+"\t\"fmt\"\n", modulePath(t), filepath.Base(outPath), body)
+	if err := os.WriteFile(filepath.Join(cmdDir, "main.go"), []byte(mainGo), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run := exec.Command("go", "run", "./"+filepath.Base(outPath)+"/cmd")
+	run.Dir = projectRoot(t)
+	run.Env = os.Environ()
+	out, err := run.CombinedOutput()
+	if err != nil {
+		t.Fatalf("generated code program failed: %v\nOutput:\n%s", err, string(out))
+	}
+	if !strings.Contains(string(out), "OK") {
+		t.Fatalf("unexpected output: %s", string(out))
+	}
+}
+
+// TestPgCompositesNoDB renders the Go structs for PostgreSQL composite types, including a
+// nested composite, an enum attribute and a composite array, and round-trips row literals.
+func TestPgCompositesNoDB(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping generated code test in short mode")
+	}
+	outPath := generatedTypesDir(t, "generated_pg_composites")
+	attr := func(name, udt, sqlType string) pgCompositeAttribute {
+		return pgCompositeAttribute{Name: name, UdtName: udt, SQLType: sqlType, FieldName: goFieldName(name), JSONName: name}
+	}
+	enums := pgEnums{
+		"ticket_status": {pgUserType: pgUserType{Name: "ticket_status", SQLName: "ticket_status", TypeName: "TicketStatus"}, Labels: []string{"open", "closed"}},
+	}
+	composites := pgComposites{
+		"address": {pgUserType: pgUserType{Name: "address", SQLName: "address", TypeName: "Address"}, Attributes: []pgCompositeAttribute{
+			attr("street", "text", "text"), attr("zip", "int4", "integer"), attr("geo", "geo_point", "geo_point"),
+			attr("tags", "_text", "text[]"), attr("status", "ticket_status", "ticket_status"),
+			attr("seen_at", "timestamptz", "timestamp with time zone"), attr("raw", "bytea", "bytea"),
+			attr("prices", "_numeric", "numeric(12,2)[]"), attr("hosts", "_inet", "inet[]"),
+		}},
+		"geo_point": {pgUserType: pgUserType{Name: "geo_point", SQLName: "geo_point", TypeName: "GeoPoint"}, Attributes: []pgCompositeAttribute{
+			attr("lat", "float8", "double precision"), attr("lng", "float8", "double precision"),
+		}},
+		"unused": {pgUserType: pgUserType{Name: "unused", SQLName: "unused", TypeName: "Unused"}, Attributes: []pgCompositeAttribute{attr("a", "text", "text")}},
+	}
+	dtMaps := pgtypes.DataTypeMap()
+	enums.register(dtMaps, "string", "pgtypes.StringArray")
+	composites.register(dtMaps, "string", "pgtypes.StringArray")
+	if got := dtMaps["address[]"](pgColumnType("addresses", "_address", "address[]")); got != "AddressArray" {
+		t.Fatalf("address[] column mapped to %s", got)
+	}
+	cfg := ConversionConfig{OutPath: outPath}
+	generatePgComposites(cfg, composites, dtMaps)
+	generatePgEnums(cfg, enums)
+
+	b, err := os.ReadFile(filepath.Join(outPath, "models", "composites.gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustContain(t, string(b), "Geo    *GeoPoint")
+	mustContain(t, string(b), "*pgtypes.DecimalArray")
+	mustContain(t, string(b), "*pgtypes.InetArray")
+	if strings.Contains(string(b), "Unused") {
+		t.Fatal("composites not referenced by a column should not be generated")
+	}
+
+	runGeneratedTypesProgram(t, outPath, `
+	var a m.Address
+	must(a.Scan(`+"`"+`("1 Main St, Apt ""2""",12345,"(1.5,-2.25)","{a,""b c""}",open,"2024-03-01 10:20:30+00",,"{1.50,2}","{10.0.0.1,10.0.0.0/8}")`+"`"+`))
+	if *a.Street != `+"`"+`1 Main St, Apt "2"`+"`"+` || *a.Zip != 12345 || *a.Geo.Lng != -2.25 || (*a.Tags)[1] != "b c" || *a.Status != m.TicketStatusOpen || a.SeenAt.Year() != 2024 || a.Raw != nil ||
+		(*a.Prices)[0].String() != "1.50" || (*a.Hosts)[1].Bits() != 8 {
+		panic(fmt.Sprintf("%+v", a))
+	}
+	v, err := a.Value()
+	must(err)
+	var back m.Address
+	must(back.Scan(v))
+	if *back.Street != *a.Street || *back.Geo.Lat != 1.5 || (*back.Tags)[1] != "b c" || !back.SeenAt.Equal(*a.SeenAt) || back.Raw != nil {
+		panic(fmt.Sprintf("round trip: %v -> %+v", v, back))
+	}
+	if err := a.Scan("(x,notanumber,,,,,,,)"); err == nil {
+		panic("bad integer accepted")
+	}
+	var arr m.AddressArray
+	must(arr.Scan(`+"`"+`{"(a,1,,,closed,,,,)","(b,2,\"(3,4)\",,,,,,)"}`+"`"+`))
+	if len(arr) != 2 || *arr[1].Geo.Lng != 4 || *arr[0].Status != m.TicketStatusClosed {
+		panic(fmt.Sprintf("%+v", arr))
+	}
+	av, err := arr.Value()
+	must(err)
+	var arr2 m.AddressArray
+	must(arr2.Scan(av))
+	if len(arr2) != 2 || *arr2[1].Geo.Lat != 3 {
+		panic(fmt.Sprintf("array round trip: %v", av))
+	}
+`)
+}
+
+// TestPgDomainsNoDB resolves domain columns through the DataTypeMap, renders the named domain
+// types and checks that Scan and Value enforce the translated CHECK constraints.
+func TestPgDomainsNoDB(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping generated code test in short mode")
+	}
+	outPath := generatedTypesDir(t, "generated_pg_domains")
+	domains := pgDomains{
+		"email": {pgUserType: pgUserType{Name: "email", SQLName: "email", TypeName: "Email"}, BaseUdt: "text", BaseType: "text",
+			Checks: []string{`CHECK ((VALUE ~* '^[^@\s]+@[^@\s]+$'::text))`, "CHECK ((char_length(VALUE) <= 64))"}},
+		"positive_int": {pgUserType: pgUserType{Name: "positive_int", SQLName: "positive_int", TypeName: "PositiveInt"}, BaseUdt: "int4", BaseType: "integer",
+			Checks: []string{"CHECK ((VALUE > 0))"}},
+		"percent": {pgUserType: pgUserType{Name: "percent", SQLName: "percent", TypeName: "Percent"}, BaseUdt: "int4", BaseType: "positive_int",
+			Checks: []string{"CHECK ((VALUE <= 100))", "CHECK ((VALUE <> ALL (ARRAY[13, 42])))"}},
+		"overlapping": {pgUserType: pgUserType{Name: "overlapping", SQLName: "overlapping", TypeName: "Overlapping"}, BaseUdt: "text", BaseType: "text",
+			Checks: []string{"CHECK ((VALUE && '{a}'::text[]))"}},
+		"amount": {pgUserType: pgUserType{Name: "amount", SQLName: "amount", TypeName: "Amount"}, BaseUdt: "numeric", BaseType: "numeric(10,2)"},
+		"positive_amount": {pgUserType: pgUserType{Name: "positive_amount", SQLName: "positive_amount", TypeName: "PositiveAmount"}, BaseUdt: "numeric", BaseType: "numeric(12,2)",
+			Checks: []string{"CHECK ((VALUE > (0)::numeric))", "CHECK ((VALUE <= 9999999999.99))"}},
+		"word": {pgUserType: pgUserType{Name: "word", SQLName: "word", TypeName: "Word"}, BaseUdt: "text", BaseType: "text",
+			Checks: []string{`CHECK ((VALUE ~ '^\m[a-z]+\M$'::text))`}},
+		"birthday": {pgUserType: pgUserType{Name: "birthday", SQLName: "birthday", TypeName: "Birthday"}, BaseUdt: "date", BaseType: "date"},
+		"unused":   {pgUserType: pgUserType{Name: "unused", SQLName: "unused", TypeName: "Unused"}, BaseUdt: "text", BaseType: "text"},
+	}
+	for _, d := range domains {
+		d.BaseDomain = domains[d.BaseType]
+	}
+	dtMaps := pgtypes.DataTypeMap()
+	resolver := &pgDomainResolver{domains: domains, typeMap: map[string]string{"amount": "float64"}, generate: true}
+	resolver.register(dtMaps)
+	for _, c := range []struct{ udt, columnType, want string }{
+		{"text", "email", "Email"},
+		{"int4", "percent", "Percent"},
+		{"int4", "positive_int", "PositiveInt"},
+		{"int4", "integer", "int32"},
+		{"text", "text", "string"},
+		{"text", "overlapping", "Overlapping"},
+		{"numeric", "amount", "float64"},
+		{"numeric", "positive_amount", "PositiveAmount"},
+		{"text", "word", "Word"},
+		{"date", "birthday", pgGoType(dtMaps, pgColumnType("c", "date", "date"))},
+	} {
+		if got := pgGoType(dtMaps, pgColumnType("c", c.udt, c.columnType)); got != c.want {
+			t.Errorf("%s column mapped to %s, want %s", c.columnType, got, c.want)
+		}
+	}
+	if got := pgGoType(dtMaps, pgColumnType("c", "_email", "email[]")); got != pgGoType(dtMaps, pgColumnType("c", "_text", "text[]")) {
+		t.Errorf("email[] column mapped to %s", got)
+	}
+	generatePgDomains(ConversionConfig{OutPath: outPath}, domains)
+
+	b, err := os.ReadFile(filepath.Join(outPath, "models", "domains.gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustContain(t, string(b), "type Percent int32")
+	mustContain(t, string(b), "CHECK ((VALUE && '{a}'::text[])) (not verified by Validate)")
+	mustContain(t, string(b), "type PositiveAmount pgtypes.Decimal")
+	mustContain(t, string(b), `CHECK ((VALUE ~ '^\m[a-z]+\M$'::text)) (not verified by Validate)`)
+	if strings.Contains(string(b), "Unused") || strings.Contains(string(b), "type Amount") {
+		t.Fatal("unused and mapped domains should not be generated")
+	}
+
+	runGeneratedTypesProgram(t, outPath, `
+	var e m.Email
+	must(e.Scan([]byte("Ann@example.com")))
+	if e != "Ann@example.com" { panic(e) }
+	if _, err := m.Email("not an email").Value(); err == nil { panic("invalid email accepted") }
+	var p m.Percent
+	must(p.Scan(int64(99)))
+	v, err := p.Value()
+	must(err)
+	if v != int64(99) { panic(v) }
+	for _, bad := range []m.Percent{0, 101, 42} {
+		if bad.Validate() == nil { panic(fmt.Sprint("invalid percent accepted: ", bad)) }
+	}
+	if err := p.Scan(nil); err == nil { panic("NULL accepted") }
+	must(m.Overlapping("anything").Validate())
+	must(m.Word("any thing").Validate())
+	var amt m.PositiveAmount
+	must(amt.Scan([]byte("12.50")))
+	av, err := amt.Value()
+	must(err)
+	if av != "12.50" { panic(av) }
+	for _, bad := range []string{"0", "-0.01", "10000000000"} {
+		if m.PositiveAmount(pgtypes.MustParseDecimal(bad)).Validate() == nil { panic("invalid amount accepted: " + bad) }
+	}
+	js, err := json.Marshal(struct{ A m.PositiveAmount }{amt})
+	must(err)
+	if string(js) != `+"`"+`{"A":"12.50"}`+"`"+` { panic(string(js)) }
+	var back struct{ A m.PositiveAmount }
+	must(json.Unmarshal(js, &back))
+	if back.A.String() != "12.50" { panic(back.A) }
+`, "encoding/json", "github.com/dan-sherwin/gormdb2struct/pgtypes")
+}
+
+// TestJSONBStructsNoDB renders structs inferred from sampled documents and a JSON Schema and
+// scans a document into them through pgtypes.JSONB.
+func TestJSONBStructsNoDB(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping generated code test in short mode")
+	}
+	outPath := generatedTypesDir(t, "generated_jsonb_structs")
+	in := newJSONBInferrer(nil, 0, nil)
+	in.goType(inferJSONShape([]string{`{"city":"Oslo","geo":{"lat":59.9},"floor":2}`, `{"city":"Bergen","geo":{"lat":60.4}}`}), "OrderShipping", "orders.shipping")
+	schema, _, _ := jsonSchemaFromComment(`{"type":"array","items":{"type":"object","properties":{"at":{"type":"string","format":"date-time"}}}}`)
+	in.goType(jsonSchemaShape(schema), "OrderEvents", "orders.events")
+	generateJSONBStructs(ConversionConfig{OutPath: outPath}, in.Structs)
+
+	b, err := os.ReadFile(filepath.Join(outPath, "models", "jsonb.gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustContain(t, string(b), "// OrderShipping is inferred for orders.shipping.")
+	mustContain(t, string(b), "Floor *int64           `json:\"floor,omitempty\"`")
+
+	runGeneratedTypesProgram(t, outPath, `
+	var s pgtypes.JSONB[m.OrderShipping]
+	must(s.Scan(`+"`"+`{"city":"Oslo","geo":{"lat":59.9}}`+"`"+`))
+	if s.Data.City != "Oslo" || s.Data.Geo.Lat != 59.9 || s.Data.Floor != nil { panic(s) }
+	v, err := s.Value()
+	must(err)
+	if v != `+"`"+`{"city":"Oslo","geo":{"lat":59.9}}`+"`"+` { panic(v) }
+	var e pgtypes.JSONB[[]m.OrderEventsItem]
+	must(e.Scan(`+"`"+`[{"at":"2024-05-01T10:00:00Z"}]`+"`"+`))
+	if e.Data[0].At.Year() != 2024 { panic(e) }
+`, "github.com/dan-sherwin/gormdb2struct/pgtypes")
+}
@@ -51,7 +51,7 @@ func TestEndToEndSQLite(t *testing.T) {
 			date_col DATE,
 			datetime_col DATETIME,
 			ts_col TIMESTAMP,
-			numeric_col NUMERIC,
+			numeric_col NUMERIC(12,2),
 			decimal_col DECIMAL,
 			duration_col DURATION,
 			json_col JSONB
@@ -147,6 +147,9 @@ Sqlitedbpath = %q
 	if err != nil {
 		t.Fatal(err)
 	}
+	mustContain(t, string(mb), "// INTEGER NOT NULL DEFAULT 0")
+	mustContain(t, string(mb), "NumericCol  *pgtypes.Decimal")
+	mustContain(t, string(mb), "type:NUMERIC(12,2);precision:12;scale:2")
 	// very simple parse: find `type <Name> struct {`
 	var modelType string
 	for _, ln := range strings.Split(string(mb), "\n") {
@@ -174,6 +177,7 @@ import (
   "fmt"
   "time"
   "gorm.io/datatypes"
+  "github.com/dan-sherwin/gormdb2struct/pgtypes"
   g "%s/%s"
   m "%s/%s/models"
 )
@@ -181,7 +185,7 @@ func main(){
   g.DbInit(%q)
   // Insert
   js := datatypes.JSONMap(map[string]any{"a": 1, "b": 2})
-  a := &m.%s{BoolCol: ptrBool(true), Tiny1: ptrStr("1"), IntCol: ptrI64(42), BigCol: ptrI64(4200), RealCol: ptrF64(1.5), DoubleCol: ptrF64(2.5), FloatCol: ptrF32(3.5), TextCol: ptrStr("hello"), VarcharCol: ptrStr("v"), CharCol: ptrStr("c"), BlobCol: ptrBytes([]byte{1,2,3}), DateCol: ptrTime(1700000000), DatetimeCol: ptrTime(1700000100), TsCol: ptrTime(1700000200), NumericCol: ptrF64(10.5), DecimalCol: ptrF64(20.5), DurationCol: ptrDur(1234567890), JSONCol: &js}
+  a := &m.%s{BoolCol: ptrBool(true), Tiny1: ptrBool(true), IntCol: ptrI64(42), BigCol: ptrI64(4200), RealCol: ptrF64(1.5), DoubleCol: ptrF64(2.5), FloatCol: ptrF32(3.5), TextCol: ptrStr("hello"), VarcharCol: ptrStr("v"), CharCol: ptrStr("c"), BlobCol: ptrBytes([]byte{1,2,3}), DateCol: ptrTime(1700000000), DatetimeCol: ptrTime(1700000100), TsCol: ptrTime(1700000200), NumericCol: ptrDec("10.50"), DecimalCol: ptrDec("20.5"), DurationCol: ptrDur(1234567890), JSONCol: &js}
   if err := g.DB.Create(a).Error; err != nil { panic(err) }
   // Read
   var got m.%s
@@ -191,7 +195,7 @@ func main(){
   jsu := datatypes.JSONMap(map[string]any{"c": 3, "d": 4})
   if err := g.DB.Model(&got).Updates(map[string]any{
     "bool_col": &b,
-    "tiny1": ptrStr("0"),
+    "tiny1": ptrBool(false),
     "int_col": ptrI64(43),
     "big_col": ptrI64(4300),
     "real_col": ptrF64(9.5),
@@ -204,19 +208,21 @@ func main(){
     "date_col": ptrTime(1700001000),
     "datetime_col": ptrTime(1700001100),
     "ts_col": ptrTime(1700001200),
-    "numeric_col": ptrF64(11.5),
-    "decimal_col": ptrF64(21.5),
+    "numeric_col": ptrDec("11.25"),
+    "decimal_col": ptrDec("21.5"),
     "duration_col": ptrDur(987654321),
     "json_col": &jsu,
   }).Error; err != nil { panic(err) }
   var after m.%s
   if err := g.DB.First(&after, a.ID).Error; err != nil { panic(err) }
   if after.TextCol == nil || *after.TextCol != "world" { panic(fmt.Sprintf("unexpected text: %%v", after.TextCol)) }
+  if after.NumericCol == nil || !after.NumericCol.Equal(pgtypes.MustParseDecimal("11.25")) { panic(fmt.Sprintf("unexpected numeric: %%v", after.NumericCol)) }
   fmt.Print("OK")
 }
 func ptrStr(s string)*string{ return &s }
 func ptrI64(v int64)*int64{ return &v }
 func ptrF64(v float64)*float64{ return &v }
+func ptrDec(s string)*pgtypes.Decimal{ d := pgtypes.MustParseDecimal(s); return &d }
 func ptrF32(v float32)*float32{ return &v }
 func ptrBool(v bool)*bool{ return &v }
 func ptrBytes(b []byte)*[]byte{ return &b }
@@ -269,3 +275,92 @@ func modulePath(t *testing.T) string {
 }
 
 func goVersion() string { return "1.24.6" }
+
+// TestSQLiteDocComments generates models from a schema carrying -- comments and checks that they
+// end up as doc comments together with each column's type and nullability.
+func TestSQLiteDocComments(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "comments.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer db.Close()
+	_, err = db.Exec(`CREATE TABLE accounts ( -- Registered users
+		id INTEGER PRIMARY KEY,
+		email TEXT NOT NULL, -- login e-mail address
+		-- Shown in the UI.
+		-- Not unique.
+		display_name TEXT,
+		created_at DATETIME NOT NULL
+	)`)
+	if err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+
+	outPath := filepath.Join(tmpDir, "out")
+	sqliteToGorm(ConversionConfig{OutPath: outPath, Sqlitedbpath: dbPath})
+	b, err := os.ReadFile(filepath.Join(outPath, "models", "accounts.gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := string(b)
+	mustContain(t, src, "// Account mapped from table <accounts>\n//\n// Registered users\ntype Account struct")
+	mustContain(t, src, "json:\"id\"` // INTEGER NOT NULL")
+	mustContain(t, src, "\t/*\n\t\tlogin e-mail address\n\t\tTEXT NOT NULL\n\t*/\n\tEmail ")
+	mustContain(t, src, "\t/*\n\t\tShown in the UI.\n\t\tNot unique.\n\t\tTEXT NULL\n\t*/\n\tDisplayName ")
+	mustContain(t, src, "// DATETIME NOT NULL")
+}
+
+// TestSQLiteJSONBColumnTypes generates a model whose JSON columns are bound to Go types through
+// JSONBColumnTypes and runs a program decoding and encoding them.
+func TestSQLiteJSONBColumnTypes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping generated code test in short mode")
+	}
+	dbPath := filepath.Join(t.TempDir(), "orders.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer db.Close()
+	_, err = db.Exec(`CREATE TABLE orders (
+		id INTEGER PRIMARY KEY,
+		shipping JSON NOT NULL,
+		hosts JSON,
+		note TEXT
+	)`)
+	if err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+
+	outPath := generatedTypesDir(t, "generated_sqlite_jsonb")
+	sqliteToGorm(ConversionConfig{OutPath: outPath, Sqlitedbpath: dbPath, JSONBColumnTypes: map[string]string{
+		"orders.shipping": "models.ShippingInfo",
+		"orders.hosts":    "[]net/netip.Addr",
+		"orders.note":     "models.ShippingInfo",
+	}})
+	b, err := os.ReadFile(filepath.Join(outPath, "models", "orders.gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := string(b)
+	mustContain(t, src, "Shipping pgtypes.JSONB[ShippingInfo]")
+	mustContain(t, src, "Hosts    *pgtypes.JSONB[[]netip.Addr]")
+	mustContain(t, src, "\"net/netip\"")
+	mustContain(t, src, "Note     *string")
+
+	shipping := "package models\n\ntype ShippingInfo struct {\n\tCity string `json:\"city\"`\n}\n"
+	if err := os.WriteFile(filepath.Join(outPath, "models", "shipping.go"), []byte(shipping), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGeneratedTypesProgram(t, outPath, `
+	var o m.Order
+	must(o.Shipping.Scan([]byte(`+"`"+`{"city":"Oslo"}`+"`"+`)))
+	if o.Shipping.Data.City != "Oslo" { panic(o.Shipping) }
+	o.Hosts = &pgtypes.JSONB[[]netip.Addr]{Data: []netip.Addr{netip.MustParseAddr("10.0.0.1")}}
+	v, err := o.Hosts.Value()
+	must(err)
+	if v != `+"`"+`["10.0.0.1"]`+"`"+` { panic(v) }
+`, "net/netip", "github.com/dan-sherwin/gormdb2struct/pgtypes")
+}
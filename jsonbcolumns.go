@@ -0,0 +1,50 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"gorm.io/gen"
+)
+
+// jsonbColumnTypes groups the JSONBColumnTypes entries ("table.column" = Go type) by table,
+// resolving each Go type as a @gotype annotation: a type of the generated models package is
+// written models.Type, any other with the import path of its package.
+func jsonbColumnTypes(entries map[string]string) map[string]map[string]annotations {
+	out := map[string]map[string]annotations{}
+	for column, goType := range entries {
+		i := strings.LastIndex(column, ".")
+		if i <= 0 || i == len(column)-1 {
+			log.Fatalf("JSONBColumnTypes: %q is not of the form table.column", column)
+		}
+		typ, importSpec, ok := parseGoTypeAnnotation(goType)
+		if !ok || strings.HasPrefix(typ, "*") {
+			log.Fatalf("JSONBColumnTypes: %q for %s is not a type such as models.Shipping or github.com/org/pkg.Shipping", goType, column)
+		}
+		elem := strings.TrimLeft(typ, "[]*")
+		typ = typ[:len(typ)-len(elem)] + strings.TrimPrefix(elem, "models.")
+		if out[column[:i]] == nil {
+			out[column[:i]] = map[string]annotations{}
+		}
+		out[column[:i]][column[i+1:]] = annotations{
+			GoType: "pgtypes.JSONB[" + typ + "]",
+			Import: importSpec,
+		}
+	}
+	return out
+}
+
+// applyJSONBColumnTypes switches the listed json and jsonb columns of a table to their
+// pgtypes.JSONB type and returns the import specs those types need.
+func applyJSONBColumnTypes(table string, fields []gen.Field, columns map[string]annotations) []string {
+	for _, f := range fields {
+		if _, ok := columns[f.ColumnName]; !ok || f.ColumnName == "" {
+			continue
+		}
+		if len(f.GORMTag["type"]) == 0 || !strings.HasPrefix(strings.ToLower(f.GORMTag["type"][0]), "json") {
+			log.Printf("warning: JSONBColumnTypes: %s.%s is not a json or jsonb column", table, f.ColumnName)
+			delete(columns, f.ColumnName)
+		}
+	}
+	return applyColumnAnnotations(fields, columns, false)
+}
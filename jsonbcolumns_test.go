@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestJSONBColumnTypes(t *testing.T) {
+	got := jsonbColumnTypes(map[string]string{
+		"orders.shipping":       "models.ShippingInfo",
+		"orders.items":          "[]github.com/acme/shop/catalog.Item",
+		"billing.invoices.meta": "models.InvoiceMeta",
+		"orders.tags":           "[]*models.Tag",
+	})
+	for _, c := range []struct{ table, column, goType, importSpec string }{
+		{"orders", "shipping", "pgtypes.JSONB[ShippingInfo]", ""},
+		{"orders", "items", "pgtypes.JSONB[[]catalog.Item]", `"github.com/acme/shop/catalog"`},
+		{"orders", "tags", "pgtypes.JSONB[[]*Tag]", ""},
+		{"billing.invoices", "meta", "pgtypes.JSONB[InvoiceMeta]", ""},
+	} {
+		if ann := got[c.table][c.column]; ann.GoType != c.goType || ann.Import != c.importSpec {
+			t.Errorf("%s.%s: got %+v", c.table, c.column, ann)
+		}
+	}
+}
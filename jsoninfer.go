@@ -0,0 +1,413 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gorm.io/gen"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// jsonShape is what a set of JSON documents, or a JSON Schema, tells about a value: the kinds
+// it takes and, for objects and arrays, the shapes of their members.
+type jsonShape struct {
+	Null, Bool, Number, String, Array, Object bool
+	Float                                     bool // a number with a fraction or exponent
+	Time                                      bool // a string of format date-time (JSON Schema only)
+	Seen                                      int  // values merged
+	Objects                                   int  // objects merged, to find the keys some of them lack
+	Optional                                  bool // a key missing from some objects, or not required
+	Fields                                    map[string]*jsonShape
+	Elem                                      *jsonShape
+}
+
+// merge adds a value decoded with UseNumber to the shape.
+func (s *jsonShape) merge(v any) {
+	s.Seen++
+	switch v := v.(type) {
+	case nil:
+		s.Null = true
+	case bool:
+		s.Bool = true
+	case json.Number:
+		s.Number = true
+		s.Float = s.Float || strings.ContainsAny(string(v), ".eE")
+	case string:
+		s.String = true
+	case []any:
+		s.Array = true
+		if s.Elem == nil {
+			s.Elem = &jsonShape{}
+		}
+		for _, e := range v {
+			s.Elem.merge(e)
+		}
+	case map[string]any:
+		s.Object = true
+		s.Objects++
+		if s.Fields == nil {
+			s.Fields = map[string]*jsonShape{}
+		}
+		for k, e := range v {
+			if s.Fields[k] == nil {
+				s.Fields[k] = &jsonShape{}
+			}
+			s.Fields[k].merge(e)
+		}
+	}
+}
+
+// markOptional marks the keys missing from some of the merged objects, recursively.
+func (s *jsonShape) markOptional() {
+	for _, f := range s.Fields {
+		f.Optional = f.Optional || f.Seen < s.Objects
+		f.markOptional()
+	}
+	if s.Elem != nil {
+		s.Elem.markOptional()
+	}
+}
+
+// inferJSONShape merges sampled documents. Documents that are not valid JSON are skipped.
+func inferJSONShape(docs []string) *jsonShape {
+	s := &jsonShape{}
+	for _, doc := range docs {
+		dec := json.NewDecoder(strings.NewReader(doc))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			continue
+		}
+		s.merge(v)
+	}
+	s.markOptional()
+	return s
+}
+
+// jsonSchemaShape converts a JSON Schema into a shape. It understands type (a name or a list of
+// names, with "null" making the value nullable), properties, required, items, nullable and the
+// date-time format; anything else, such as $ref or oneOf, becomes any.
+func jsonSchemaShape(schema map[string]any) *jsonShape {
+	s := &jsonShape{Seen: 1}
+	types := []string{}
+	switch t := schema["type"].(type) {
+	case string:
+		types = append(types, t)
+	case []any:
+		for _, e := range t {
+			if name, ok := e.(string); ok {
+				types = append(types, name)
+			}
+		}
+	case nil:
+		if _, ok := schema["properties"]; ok {
+			types = append(types, "object")
+		} else if _, ok := schema["items"]; ok {
+			types = append(types, "array")
+		}
+	}
+	for _, t := range types {
+		switch t {
+		case "null":
+			s.Null = true
+		case "boolean":
+			s.Bool = true
+		case "integer":
+			s.Number = true
+		case "number":
+			s.Number, s.Float = true, true
+		case "string":
+			s.String = true
+			s.Time = schema["format"] == "date-time"
+		case "array":
+			s.Array = true
+			if items, ok := schema["items"].(map[string]any); ok {
+				s.Elem = jsonSchemaShape(items)
+			}
+		case "object":
+			s.Object = true
+			s.Fields = map[string]*jsonShape{}
+			required := map[string]bool{}
+			if list, ok := schema["required"].([]any); ok {
+				for _, e := range list {
+					if name, ok := e.(string); ok {
+						required[name] = true
+					}
+				}
+			}
+			properties, _ := schema["properties"].(map[string]any)
+			for k, p := range properties {
+				ps, _ := p.(map[string]any)
+				s.Fields[k] = jsonSchemaShape(ps)
+				s.Fields[k].Optional = !required[k]
+			}
+		}
+	}
+	if nullable, _ := schema["nullable"].(bool); nullable {
+		s.Null = true
+	}
+	return s
+}
+
+// jsonSchemaFromComment finds a JSON Schema, a JSON object with a type, properties or $schema
+// key, in a column comment. It returns the schema and the comment without it.
+func jsonSchemaFromComment(comment string) (schema map[string]any, rest string, ok bool) {
+	i := strings.Index(comment, "{")
+	if i < 0 {
+		return nil, comment, false
+	}
+	dec := json.NewDecoder(strings.NewReader(comment[i:]))
+	if err := dec.Decode(&schema); err != nil {
+		return nil, comment, false
+	}
+	_, hasType := schema["type"]
+	_, hasProperties := schema["properties"]
+	_, hasSchema := schema["$schema"]
+	if !hasType && !hasProperties && !hasSchema {
+		return nil, comment, false
+	}
+	end := i + int(dec.InputOffset())
+	return schema, strings.TrimSpace(comment[:i] + comment[end:]), true
+}
+
+// jsonStruct is a Go struct inferred for a JSON object.
+type jsonStruct struct {
+	Name   string
+	Source string // table.column it was inferred for
+	Fields []jsonStructField
+}
+
+type jsonStructField struct {
+	Name     string
+	GoType   string
+	JSONName string
+	Optional bool
+}
+
+// jsonbInferrer generates Go structs for json and jsonb columns, from a JSON Schema in the
+// column comment or from sampled rows.
+type jsonbInferrer struct {
+	db         *gorm.DB
+	sampleRows int
+	names      map[string]bool // type names taken in the models package
+	Structs    []*jsonStruct
+}
+
+// newJSONBInferrer returns an inferrer whose structs avoid the names in taken: the models and
+// the enum, composite and domain types generated into the same package.
+func newJSONBInferrer(db *gorm.DB, sampleRows int, taken []string) *jsonbInferrer {
+	in := &jsonbInferrer{db: db, sampleRows: sampleRows, names: map[string]bool{}}
+	for _, name := range taken {
+		in.names[name] = true
+	}
+	return in
+}
+
+// extractSchemas removes the JSON Schemas from the comments of a table's json and jsonb
+// columns, so that they stay out of the doc comments, and returns them by column.
+func extractSchemas(fields []gen.Field, comments map[string]string) map[string]map[string]any {
+	schemas := map[string]map[string]any{}
+	for _, f := range fields {
+		if !isJSONField(f) {
+			continue
+		}
+		if schema, rest, ok := jsonSchemaFromComment(comments[f.ColumnName]); ok {
+			schemas[f.ColumnName], comments[f.ColumnName] = schema, rest
+		}
+	}
+	return schemas
+}
+
+func isJSONField(f gen.Field) bool {
+	if f.ColumnName == "" || len(f.GORMTag["type"]) == 0 {
+		return false
+	}
+	t := strings.ToLower(f.GORMTag["type"][0])
+	return t == "json" || t == "jsonb"
+}
+
+// inferColumns switches the json and jsonb columns of a model to pgtypes.JSONB of a generated
+// struct. Columns for which skip is true, and those whose documents have no useful shape, keep
+// their type.
+func (in *jsonbInferrer) inferColumns(table, modelName string, fields []gen.Field, schemas map[string]map[string]any, skip func(column string) bool) {
+	for _, f := range fields {
+		if !isJSONField(f) || skip(f.ColumnName) {
+			continue
+		}
+		var shape *jsonShape
+		if schema, ok := schemas[f.ColumnName]; ok {
+			shape = jsonSchemaShape(schema)
+		} else {
+			docs, err := in.sample(table, f.ColumnName)
+			if err != nil {
+				log.Printf("warning: cannot sample %s.%s: %v", table, f.ColumnName, err)
+				continue
+			}
+			shape = inferJSONShape(docs)
+			if shape.Seen == 0 {
+				log.Printf("warning: no rows to infer the type of %s.%s from; keeping %s", table, f.ColumnName, f.Type)
+				continue
+			}
+		}
+		goType := in.goType(shape, modelName+f.Name, table+"."+f.ColumnName)
+		if goType == "any" || goType == "[]any" || strings.HasPrefix(goType, "map[") {
+			continue
+		}
+		ptr := ""
+		if strings.HasPrefix(f.Type, "*") {
+			ptr = "*"
+		}
+		f.Type = ptr + "pgtypes.JSONB[" + goType + "]"
+	}
+}
+
+// sample reads up to sampleRows non-NULL documents of a column.
+func (in *jsonbInferrer) sample(table, column string) ([]string, error) {
+	docs := []string{}
+	err := in.db.Table(table).
+		Where(clause.Expr{SQL: "? IS NOT NULL", Vars: []any{clause.Column{Name: column}}}).
+		Limit(in.sampleRows).Pluck(column, &docs).Error
+	return docs, err
+}
+
+// goType returns the Go type of a shape, adding the structs it needs under names starting with
+// name. Values of mixed kinds, and values only ever null, are any.
+func (in *jsonbInferrer) goType(s *jsonShape, name, source string) string {
+	kinds := 0
+	for _, k := range []bool{s.Bool, s.Number, s.String, s.Array, s.Object} {
+		if k {
+			kinds++
+		}
+	}
+	switch {
+	case kinds != 1:
+		return "any"
+	case s.Bool:
+		return "bool"
+	case s.Number && s.Float:
+		return "float64"
+	case s.Number:
+		return "int64"
+	case s.String && s.Time:
+		return "time.Time"
+	case s.String:
+		return "string"
+	case s.Array:
+		if s.Elem == nil {
+			return "[]any"
+		}
+		return "[]" + in.goType(s.Elem, name+"Item", source)
+	case len(s.Fields) == 0 || !jsonTagKeys(s.Fields):
+		return "map[string]any"
+	}
+	st := &jsonStruct{Name: in.typeName(name), Source: source}
+	in.Structs = append(in.Structs, st)
+	keys := make([]string, 0, len(s.Fields))
+	for k := range s.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fieldNames := map[string]bool{}
+	for _, k := range keys {
+		f := s.Fields[k]
+		fieldName := uniqueName(jsonFieldName(k), fieldNames)
+		fieldNames[fieldName] = true
+		goType := in.goType(f, st.Name+fieldName, source)
+		optional := f.Optional || f.Null
+		if optional && goType != "any" && !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map[") {
+			goType = "*" + goType
+		}
+		st.Fields = append(st.Fields, jsonStructField{Name: fieldName, GoType: goType, JSONName: k, Optional: optional})
+	}
+	return st.Name
+}
+
+func (in *jsonbInferrer) typeName(name string) string {
+	name = uniqueName(name, in.names)
+	in.names[name] = true
+	return name
+}
+
+// uniqueName appends a number to name until it is not taken.
+func uniqueName(name string, taken map[string]bool) string {
+	for i, base := 2, name; taken[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	return name
+}
+
+// jsonTagKeys reports whether every key can name a field in a json struct tag. encoding/json
+// cuts a name at the first comma, ignores a field tagged "-", and falls back to the Go field
+// name for an empty name or one with other punctuation, so objects with such keys stay maps.
+func jsonTagKeys(fields map[string]*jsonShape) bool {
+	for k := range fields {
+		if k == "" || k == "-" {
+			return false
+		}
+		for _, r := range k {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) &&
+				!strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// jsonFieldName names the struct field of a JSON key, as goFieldName does for columns, dropping
+// characters that cannot appear in an identifier.
+func jsonFieldName(key string) string {
+	name := goFieldName(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, key))
+	name = strings.Trim(name, "_")
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "F" + name
+	}
+	return name
+}
+
+// generateJSONBStructs writes the inferred structs into the models package.
+func generateJSONBStructs(cfg ConversionConfig, structs []*jsonStruct) {
+	if len(structs) == 0 {
+		return
+	}
+	writeModelsFile(cfg.OutPath, "jsonb.gen.go", jsonbStructsTemplate, struct {
+		Structs []*jsonStruct
+	}{structs})
+}
+
+// Tag returns the struct tag of an inferred field.
+func (f jsonStructField) Tag() string {
+	name := f.JSONName
+	if f.Optional {
+		name += ",omitempty"
+	}
+	return "`json:" + strconv.Quote(name) + "`"
+}
+
+var jsonbStructsTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+// Go structs inferred for json and jsonb columns, from sampled rows or a JSON Schema in the
+// column comment.
+
+package models
+
+import (
+	"time"
+)
+{{range .Structs}}
+// {{.Name}} is inferred for {{.Source}}.
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.GoType}} {{.Tag}}
+{{- end}}
+}
+{{end}}`
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInferJSONStructs(t *testing.T) {
+	shape := inferJSONShape([]string{
+		`{"city":"Oslo","zip":"0150","geo":{"lat":59.9,"lng":10.7},"tags":["a"],"floor":2}`,
+		`{"city":"Bergen","geo":{"lat":60,"lng":5},"tags":[],"floor":null,"mixed":1}`,
+		`{"city":"Tromsø","geo":{"lat":69.6,"lng":18.9},"tags":["b"],"floor":3,"mixed":"x","user-id":7}`,
+		`not json`,
+	})
+	in := newJSONBInferrer(nil, 0, []string{"OrderShippingGeo"})
+	if got := in.goType(shape, "OrderShipping", "orders.shipping"); got != "OrderShipping" {
+		t.Fatalf("root type %s", got)
+	}
+	if len(in.Structs) != 2 || in.Structs[1].Name != "OrderShippingGeo2" {
+		t.Fatalf("structs %+v", in.Structs)
+	}
+	want := []jsonStructField{
+		{"City", "string", "city", false},
+		{"Floor", "*int64", "floor", true},
+		{"Geo", "OrderShippingGeo2", "geo", false},
+		{"Mixed", "any", "mixed", true},
+		{"Tags", "[]string", "tags", false},
+		{"UserID", "*int64", "user-id", true},
+		{"Zip", "*string", "zip", true},
+	}
+	if !reflect.DeepEqual(in.Structs[0].Fields, want) {
+		t.Errorf("fields\n got %+v\nwant %+v", in.Structs[0].Fields, want)
+	}
+	if got := in.Structs[1].Fields[0]; got.GoType != "float64" || got.Tag() != "`json:\"lat\"`" {
+		t.Errorf("lat: %+v", got)
+	}
+	if tag := want[1].Tag(); tag != "`json:\"floor,omitempty\"`" {
+		t.Errorf("tag %s", tag)
+	}
+
+	// Keys a json tag cannot name keep their object a map rather than drop data.
+	for _, doc := range []string{`{"id":1,"-":2}`, `{"id":1,"a,b":2}`} {
+		shape := inferJSONShape([]string{`{"meta":` + doc + `}`})
+		in := newJSONBInferrer(nil, 0, nil)
+		if in.goType(shape, "Event", "events.data"); len(in.Structs) != 1 || in.Structs[0].Fields[0].GoType != "map[string]any" {
+			t.Errorf("%s: %+v", doc, in.Structs)
+		}
+		if got := in.goType(inferJSONShape([]string{doc}), "Other", "events.data"); got != "map[string]any" {
+			t.Errorf("%s: root type %s", doc, got)
+		}
+	}
+}
+
+func TestJSONSchemaColumns(t *testing.T) {
+	comment := `Shipping address. {"type":"object","required":["city"],"properties":{` +
+		`"city":{"type":"string"},"at":{"type":"string","format":"date-time"},` +
+		`"lines":{"type":"array","items":{"type":"object","properties":{"sku":{"type":"string"},"qty":{"type":"integer"}}}},` +
+		`"note":{"type":["string","null"]}}} Kept up to date by the shop.`
+	schema, rest, ok := jsonSchemaFromComment(comment)
+	if !ok || rest != "Shipping address.  Kept up to date by the shop." {
+		t.Fatalf("extract: %v %q", ok, rest)
+	}
+	if _, _, ok := jsonSchemaFromComment(`Tags such as {"a": 1}`); ok {
+		t.Fatal("an object without type or properties is not a schema")
+	}
+	in := newJSONBInferrer(nil, 0, nil)
+	if got := in.goType(jsonSchemaShape(schema), "OrderShipping", "orders.shipping"); got != "OrderShipping" {
+		t.Fatalf("root type %s", got)
+	}
+	fields := map[string]string{}
+	for _, f := range in.Structs[0].Fields {
+		fields[f.Name] = f.GoType
+	}
+	if !reflect.DeepEqual(fields, map[string]string{"At": "*time.Time", "City": "string", "Lines": "[]OrderShippingLinesItem", "Note": "*string"}) {
+		t.Errorf("fields %v", fields)
+	}
+	if len(in.Structs) != 2 || in.Structs[1].Name != "OrderShippingLinesItem" || in.Structs[1].Fields[0].GoType != "*int64" {
+		t.Errorf("item struct %+v", in.Structs[1:])
+	}
+
+	composite := &pgUserType{Name: "order_shipping", SQLName: "order_shipping", TypeName: "OrderShipping"}
+	in = newJSONBInferrer(nil, 0, pgUserTypeNames(nil, []*pgUserType{composite}))
+	if got := in.goType(jsonSchemaShape(schema), "OrderShipping", "orders.shipping"); got != "OrderShipping2" {
+		t.Fatalf("root type %s clashes with a composite type", got)
+	}
+}
@@ -26,7 +26,17 @@ type (
 		ExtraFields             map[string][]ExtraField
 		TypeMap                 map[string]string
 		DomainTypeMap           map[string]string
+		GenerateDomainTypes     bool
+		NullableArrayColumns    []string
+		JSONBColumnTypes        map[string]string
+		InferJSONBTypes         bool
+		JSONBSampleRows         int
 		NamingStrategy          schema.NamingStrategy
+		IncludeTables           []string
+		ExcludeTables           []string
+		GenerateRelations       bool
+		SkipRelationsForTables  []string
+		DetectManyToMany        bool
 		CleanUp                 bool
 		GenerateDbInit          bool
 		IncludeAutoMigrate      bool
@@ -36,6 +46,7 @@ type (
 		DbUser                  string
 		DbPassword              string
 		DbSSLMode               bool
+		Schemas                 []string
 		Sqlitedbpath            string
 	}
 
@@ -45,7 +56,14 @@ type (
 		FkStructPropName  string //Struct prpoerty name that is used in the foreign key
 		RefStructPropName string //Struct property name of the referenced table struct
 		HasMany           bool   // A one-one or one-to-many relationship
+		BelongsTo         bool   // The foreign key lives on this table and references RefStructPropName on the property's type
 		Pointer           bool   // Should the added property be a pointer
+		// Many2Many names the join table of a many-to-many relationship; the property becomes a slice.
+		// FkStructPropName/RefStructPropName are then the keys on this struct and on the property's type,
+		// JoinFkStructPropName/JoinRefStructPropName the join table columns referencing them (all optional).
+		Many2Many             string
+		JoinFkStructPropName  string
+		JoinRefStructPropName string
 	}
 )
 
@@ -69,6 +87,7 @@ var (
 		ImportPackagePaths: []string{
 			"github.com/dan-sherwin/gormdb2struct/pgtypes",
 		},
+		JSONBSampleRows: 100,
 	}
 	//extraFields    = map[string][]ExtraField{
 	//"ticket_extended": {
@@ -94,11 +113,12 @@ func usage(exitCode int, errMsg string) {
 	if strings.TrimSpace(errMsg) != "" {
 		fmt.Fprintf(os.Stderr, "Error: %s\n\n", errMsg)
 	}
-	fmt.Fprintf(os.Stderr, "Usage:\n  %s <config.toml>\n  %s -generateConfigSample\n  %s -version | --version\n\n", prog, prog, prog)
+	fmt.Fprintf(os.Stderr, "Usage:\n  %s <config.toml> [--tables pattern,...]\n  %s -generateConfigSample\n  %s -version | --version\n\n", prog, prog, prog)
 	fmt.Fprintln(os.Stderr, "Description:")
 	fmt.Fprintln(os.Stderr, "  Generates GORM models and optional DB initializer code from an existing database.")
 	fmt.Fprintln(os.Stderr, "  Provide a TOML configuration file describing the database and generation options.")
 	fmt.Fprintln(os.Stderr, "  Use -generateConfigSample to write a sample configuration file named 'gormdb2struct-sample.toml' in the current directory.")
+	fmt.Fprintln(os.Stderr, "  Use --tables to override IncludeTables with a comma-separated list of globs or /regexes/.")
 	os.Exit(exitCode)
 }
 
@@ -116,10 +136,28 @@ func main() {
 		fmt.Fprintf(os.Stdout, "Sample config written to %s\n", out)
 		return
 	}
-	if len(os.Args) != 2 {
+	args := []string{}
+	var tablesOverride *string
+	for i := 1; i < len(os.Args); i++ {
+		arg := os.Args[i]
+		switch {
+		case arg == "-tables" || arg == "--tables":
+			if i+1 >= len(os.Args) {
+				usage(2, "--tables requires a comma-separated list of table patterns")
+			}
+			i++
+			tablesOverride = &os.Args[i]
+		case strings.HasPrefix(arg, "-tables=") || strings.HasPrefix(arg, "--tables="):
+			v := arg[strings.Index(arg, "=")+1:]
+			tablesOverride = &v
+		default:
+			args = append(args, arg)
+		}
+	}
+	if len(args) != 1 {
 		usage(2, "exactly one argument is required: path to a TOML config file or -generateConfigSample")
 	}
-	cfgPath := os.Args[1]
+	cfgPath := args[0]
 	if _, err := os.Stat(cfgPath); err != nil {
 		usage(2, fmt.Sprintf("cannot access config file %s: %v", cfgPath, err))
 	}
@@ -140,6 +178,10 @@ func main() {
 	if cfg.JsonTagOverridesByTable == nil {
 		cfg.JsonTagOverridesByTable = map[string]map[string]string{}
 	}
+	// --tables replaces IncludeTables from the config file
+	if tablesOverride != nil {
+		cfg.IncludeTables = splitTablePatterns(*tablesOverride)
+	}
 	// Merge defaults from conversionConfig into cfg when not defined in the imported config
 	// Merge TypeMap
 	for k, v := range conversionConfig.TypeMap {
@@ -153,6 +195,10 @@ func main() {
 			cfg.DomainTypeMap[k] = v
 		}
 	}
+	// Merge JSONBSampleRows
+	if cfg.JSONBSampleRows <= 0 {
+		cfg.JSONBSampleRows = conversionConfig.JSONBSampleRows
+	}
 	// Merge ImportPackagePaths (append missing entries while preserving order)
 	existing := map[string]struct{}{}
 	for _, p := range cfg.ImportPackagePaths {
@@ -171,6 +217,9 @@ func main() {
 	if cfg.DatabaseDialect != POSTGRESQL && cfg.DatabaseDialect != SQLITE {
 		usage(2, fmt.Sprintf("configuration error: DatabaseDialect must be '%s' or '%s'", POSTGRESQL, SQLITE))
 	}
+	if _, err := newTableFilter(cfg.IncludeTables, cfg.ExcludeTables); err != nil {
+		usage(2, fmt.Sprintf("configuration error: %v", err))
+	}
 	if cfg.DatabaseDialect == POSTGRESQL {
 		if cfg.DbPort == 0 {
 			cfg.DbPort = 5432
@@ -206,7 +255,7 @@ func genRelationField(ef *ExtraField, fld gen.Field) {
 	if ef.Pointer {
 		baseType = "*" + baseType
 	}
-	if ef.HasMany {
+	if ef.HasMany || ef.Many2Many != "" {
 		baseType = "[]" + baseType
 	}
 	fld.Name = ef.StructPropName
@@ -215,11 +264,28 @@ func genRelationField(ef *ExtraField, fld gen.Field) {
 	t.Set("json", strcase.ToLowerCamel(ef.StructPropName))
 	fld.Tag = t
 	fld.GORMTag = field.GormTag{}
+	if ef.Many2Many != "" {
+		fld.GORMTag.Set("many2many", ef.Many2Many)
+		for k, v := range map[string]string{
+			"foreignKey":     ef.FkStructPropName,
+			"joinForeignKey": ef.JoinFkStructPropName,
+			"references":     ef.RefStructPropName,
+			"joinReferences": ef.JoinRefStructPropName,
+		} {
+			if v != "" {
+				fld.GORMTag.Set(k, v)
+			}
+		}
+		fld.Relation = field.NewRelationWithType(field.Many2Many, ef.StructPropName, ef.StructPropType)
+		return
+	}
 	fld.GORMTag.Set("foreignKey", ef.FkStructPropName)
 	fld.GORMTag.Set("references", ef.RefStructPropName)
 	r := field.HasOne
 	if ef.HasMany {
 		r = field.HasMany
+	} else if ef.BelongsTo {
+		r = field.BelongsTo
 	}
 	fld.Relation = field.NewRelationWithType(
 		r,
@@ -253,14 +319,60 @@ ImportPackagePaths = [
   "github.com/dan-sherwin/gormdb2struct/pgtypes",
 ]
 
+# IncludeTables / ExcludeTables: limit which tables are generated (optional).
+# Entries are globs (e.g. "billing.*", "tmp_*") or regular expressions wrapped in slashes.
+# Patterns match the bare table name and, for PostgreSQL, the schema-qualified name.
+# An empty IncludeTables means all tables; ExcludeTables always wins.
+# The --tables command line flag replaces IncludeTables.
+IncludeTables = []
+ExcludeTables = ["schema_migrations", "goose_db_version", "spatial_ref_sys"]
+
+# Schemas: PostgreSQL schemas to introspect (optional, defaults to ["public"]).
+# Tables outside public get schema-qualified table names (e.g. "billing.invoices")
+# and are keyed that way in the per-table options below.
+Schemas = ["public"]
+
+# GenerateRelations: derive relation fields from foreign key constraints (optional).
+# The referencing model gets a BelongsTo field, the referenced model a HasOne/HasMany field.
+# Relations already declared in ExtraFields are not duplicated.
+GenerateRelations = false
+# SkipRelationsForTables: tables that should not receive generated relation fields
+SkipRelationsForTables = []
+# DetectManyToMany: turn pure join tables (two foreign keys forming the primary key, no other columns)
+# into many2many slice fields on both sides instead of generating a model for them (optional)
+DetectManyToMany = false
+
+# GenerateDomainTypes: generate a named Go type for each PostgreSQL domain over a string, bool or
+# numeric type, with a Validate method derived from the domain's CHECK constraints (optional).
+# Domains listed in DomainTypeMap keep their mapped type.
+GenerateDomainTypes = false
+
+# NullableArrayColumns: PostgreSQL array columns ("table.column") whose elements may be NULL (optional).
+# They use pgtypes.NullableArray[T], a []*T that keeps NULL elements, instead of pgtypes.Array[T].
+NullableArrayColumns = []
+
+# InferJSONBTypes: generate a Go struct for each json/jsonb column (PostgreSQL) from a JSON Schema in the
+# column comment, or else from up to JSONBSampleRows sampled rows (default 100), and use it as
+# pgtypes.JSONB[T] (optional). Columns in JSONBColumnTypes or with a @gotype annotation are left alone.
+InferJSONBTypes = false
+JSONBSampleRows = 100
+
 # TypeMap: database column type overrides (optional)
 [TypeMap]
 # "jsonb" = "datatypes.JSONMap"
 # "uuid"  = "datatypes.UUID"
 
-# DomainTypeMap: map database domain names to Go types (optional)
+# DomainTypeMap: map database domain names to Go types (optional).
+# Domains over any base type can be mapped; unmapped domains use their base type's Go type.
 [DomainTypeMap]
 # "my_text_domain" = "string"
+# "positive_int"   = "int64"
+
+# JSONBColumnTypes: decode json/jsonb columns ("table.column") into a Go type as pgtypes.JSONB[T] (optional).
+# Types of the generated models package are written models.Type; others with their import path.
+[JSONBColumnTypes]
+# "orders.shipping" = "models.ShippingInfo"
+# "orders.items"    = "[]github.com/acme/shop/catalog.Item"
 
 # ExtraFields: add relation fields to specific models (optional)
 [ExtraFields]
@@ -270,8 +382,12 @@ ImportPackagePaths = [
 #   StructPropType = "models.Attachment"  # fully-qualified type
 #   FkStructPropName = "TicketID"
 #   RefStructPropName = "TicketID"
-#   HasMany = true
+#   HasMany = true      # or BelongsTo = true when the foreign key is on this table
 #   Pointer = true
+#   # Many-to-many through a join table:
+#   # Many2Many = "user_roles"
+#   # JoinFkStructPropName = "UserID"
+#   # JoinRefStructPropName = "RoleID"
 
 # JsonTagOverridesByTable: override json tags for fields (optional)
 [JsonTagOverridesByTable]
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"gorm.io/gen"
+)
+
+// nullableArrayType returns the pgtypes.NullableArray type for a one-dimensional array Go type.
+// A leading pointer is kept.
+func nullableArrayType(goType string, userTypes map[string]bool) (string, bool) {
+	ptr, elem, ok := pgArrayElementType(goType, userTypes)
+	if !ok {
+		return "", false
+	}
+	return ptr + "pgtypes.NullableArray[" + elem + "]", true
+}
+
+// nullableArrayColumns groups the NullableArrayColumns entries ("table.column", where table is
+// the name used by the per-table options) by table.
+func nullableArrayColumns(entries []string) map[string]map[string]bool {
+	out := map[string]map[string]bool{}
+	for _, e := range entries {
+		i := strings.LastIndex(e, ".")
+		if i <= 0 || i == len(e)-1 {
+			log.Fatalf("NullableArrayColumns: %q is not of the form table.column", e)
+		}
+		if out[e[:i]] == nil {
+			out[e[:i]] = map[string]bool{}
+		}
+		out[e[:i]][e[i+1:]] = true
+	}
+	return out
+}
+
+// applyNullableArrayColumns switches the listed array columns of a table to NullableArray, so
+// NULL elements survive Scan and Value.
+func applyNullableArrayColumns(table string, fields []gen.Field, columns map[string]bool, userTypes map[string]bool) {
+	for _, f := range fields {
+		if f.ColumnName == "" || !columns[f.ColumnName] {
+			continue
+		}
+		goType, ok := nullableArrayType(f.Type, userTypes)
+		if !ok {
+			log.Printf("warning: NullableArrayColumns: %s.%s has type %s, which is not a one-dimensional array type", table, f.ColumnName, f.Type)
+			continue
+		}
+		f.Type = goType
+	}
+}
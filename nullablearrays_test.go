@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestNullableArrayType(t *testing.T) {
+	userTypes := map[string]bool{"TicketStatus": true}
+	for in, want := range map[string]string{
+		"pgtypes.StringArray":            "pgtypes.NullableArray[string]",
+		"*pgtypes.Int64Array":            "*pgtypes.NullableArray[int64]",
+		"pgtypes.DecimalArray":           "pgtypes.NullableArray[pgtypes.Decimal]",
+		"pgtypes.Array[json.RawMessage]": "pgtypes.NullableArray[json.RawMessage]",
+		"*TicketStatusArray":             "*pgtypes.NullableArray[TicketStatus]",
+		"OtherArray":                     "",
+		"string":                         "",
+	} {
+		got, ok := nullableArrayType(in, userTypes)
+		if got != want || ok != (want != "") {
+			t.Errorf("nullableArrayType(%q) = %q, %v, want %q", in, got, ok, want)
+		}
+	}
+	cols := nullableArrayColumns([]string{"tickets.labels", "billing.invoices.lines"})
+	if !cols["tickets"]["labels"] || !cols["billing.invoices"]["lines"] {
+		t.Fatalf("unexpected columns: %v", cols)
+	}
+}
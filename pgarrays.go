@@ -0,0 +1,116 @@
+package main
+
+import (
+	"log"
+	"regexp"
+	"strings"
+
+	"gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+var pgTypeModifierRegexp = regexp.MustCompile(`\s*\([^)]*\)`)
+
+// pgUnmodifiedTypeName strips the type modifiers from a formatted type, so that array columns
+// reported as numeric(12,2)[] or timestamp(3) with time zone[] find the numeric[] and
+// timestamp with time zone[] DataTypeMap entries.
+func pgUnmodifiedTypeName(name string) string {
+	return pgTypeModifierRegexp.ReplaceAllString(name, "")
+}
+
+// addModifiedArrayTypes gives every array column of table declared with a type modifier the
+// DataTypeMap entry of its unmodified type. gen looks columns up by their exact type name.
+func addModifiedArrayTypes(columnTypes []gorm.ColumnType, dtMaps map[string]func(gorm.ColumnType) string) {
+	for _, ct := range columnTypes {
+		name := ct.DatabaseTypeName()
+		if _, ok := dtMaps[name]; ok || !strings.HasSuffix(name, "[]") {
+			continue
+		}
+		if mapping, ok := dtMaps[pgUnmodifiedTypeName(name)]; ok {
+			dtMaps[name] = mapping
+		}
+	}
+}
+
+// pgtypesArrayElements maps the named pgtypes arrays to their element types.
+var pgtypesArrayElements = map[string]string{
+	"pgtypes.StringArray":    "string",
+	"pgtypes.Int32Array":     "int32",
+	"pgtypes.Int64Array":     "int64",
+	"pgtypes.Float64Array":   "float64",
+	"pgtypes.BoolArray":      "bool",
+	"pgtypes.UUIDArray":      "uuid.UUID",
+	"pgtypes.TimeArray":      "time.Time",
+	"pgtypes.DecimalArray":   "pgtypes.Decimal",
+	"pgtypes.IntervalArray":  "pgtypes.Interval",
+	"pgtypes.DurationArray":  "pgtypes.Duration",
+	"pgtypes.InetArray":      "pgtypes.Inet",
+	"pgtypes.CIDRArray":      "pgtypes.CIDR",
+	"pgtypes.MacAddrArray":   "pgtypes.MacAddr",
+	"pgtypes.HstoreArray":    "pgtypes.Hstore",
+	"pgtypes.LTreeArray":     "pgtypes.LTree",
+	"pgtypes.CITextArray":    "pgtypes.CIText",
+	"pgtypes.VectorArray":    "pgtypes.Vector",
+	"pgtypes.TSVectorArray":  "pgtypes.TSVector",
+	"pgtypes.TSQueryArray":   "pgtypes.TSQuery",
+	"pgtypes.BitStringArray": "pgtypes.BitString",
+	"pgtypes.MoneyArray":     "pgtypes.Money",
+	"pgtypes.XMLArray":       "pgtypes.XML",
+}
+
+// pgArrayElementType returns the element type of a one-dimensional array Go type: a named
+// pgtypes array, a pgtypes.Array[T], or a generated enum or composite array such as
+// TicketStatusArray. ptr is the leading pointer gen adds for nullable columns.
+func pgArrayElementType(goType string, userTypes map[string]bool) (ptr, elem string, ok bool) {
+	if strings.HasPrefix(goType, "*") {
+		ptr, goType = "*", goType[1:]
+	}
+	if elem, ok := pgtypesArrayElements[goType]; ok {
+		return ptr, elem, true
+	}
+	if strings.HasPrefix(goType, "pgtypes.Array[") && strings.HasSuffix(goType, "]") {
+		return ptr, strings.TrimSuffix(strings.TrimPrefix(goType, "pgtypes.Array["), "]"), true
+	}
+	if name, found := strings.CutSuffix(goType, "Array"); found && userTypes[name] {
+		return ptr, name, true
+	}
+	return "", "", false
+}
+
+// multiDimArrayType returns the pgtypes type for an array column declared with dims > 1
+// dimensions, given the Go type of its one-dimensional form: Array2D, Array3D, or ArrayND for
+// more dimensions.
+func multiDimArrayType(goType string, dims int, userTypes map[string]bool) (string, bool) {
+	ptr, elem, ok := pgArrayElementType(goType, userTypes)
+	if !ok {
+		return "", false
+	}
+	switch dims {
+	case 2:
+		return ptr + "pgtypes.Array2D[" + elem + "]", true
+	case 3:
+		return ptr + "pgtypes.Array3D[" + elem + "]", true
+	}
+	return ptr + "pgtypes.ArrayND[" + elem + "]", true
+}
+
+// applyArrayDimensions maps the array columns of a table declared with more than one dimension
+// (attndims > 1, as in integer[][]) to the multi-dimensional pgtypes arrays. PostgreSQL reports
+// them with the type of a one-dimensional array, so they otherwise get a flat Array.
+func applyArrayDimensions(table string, fields []gen.Field, dims map[string]int, userTypes map[string]bool) {
+	for _, f := range fields {
+		n := dims[f.ColumnName]
+		if f.ColumnName == "" || n < 2 {
+			continue
+		}
+		goType, ok := multiDimArrayType(f.Type, n, userTypes)
+		if !ok {
+			log.Printf("warning: %s.%s is a %d-dimensional array of type %s, which has no multi-dimensional form", table, f.ColumnName, n, f.Type)
+			continue
+		}
+		f.Type = goType
+		if tag := f.GORMTag["type"]; len(tag) == 1 && strings.HasSuffix(tag[0], "[]") {
+			f.GORMTag.Set("type", tag[0]+strings.Repeat("[]", n-1))
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+func TestPgUnmodifiedTypeName(t *testing.T) {
+	for in, want := range map[string]string{
+		"numeric(12,2)[]":               "numeric[]",
+		"character varying(64)[]":       "character varying[]",
+		"timestamp(3) with time zone[]": "timestamp with time zone[]",
+		"bit(3)[]":                      "bit[]",
+		"integer[]":                     "integer[]",
+	} {
+		if got := pgUnmodifiedTypeName(in); got != want {
+			t.Errorf("pgUnmodifiedTypeName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestApplyArrayDimensions(t *testing.T) {
+	userTypes := map[string]bool{"TicketStatus": true}
+	fields := []gen.Field{
+		{ColumnName: "matrix", Type: "pgtypes.Int32Array", GORMTag: field.GormTag{"type": {"integer[]"}}},
+		{ColumnName: "pairs", Type: "*pgtypes.StringArray", GORMTag: field.GormTag{}},
+		{ColumnName: "cube", Type: "pgtypes.Array[float32]", GORMTag: field.GormTag{}},
+		{ColumnName: "deep", Type: "TicketStatusArray", GORMTag: field.GormTag{}},
+		{ColumnName: "flat", Type: "pgtypes.Int64Array", GORMTag: field.GormTag{}},
+	}
+	applyArrayDimensions("t", fields, map[string]int{"matrix": 2, "pairs": 2, "cube": 3, "deep": 5}, userTypes)
+	for i, want := range []string{"pgtypes.Array2D[int32]", "*pgtypes.Array2D[string]", "pgtypes.Array3D[float32]", "pgtypes.ArrayND[TicketStatus]", "pgtypes.Int64Array"} {
+		if fields[i].Type != want {
+			t.Errorf("%s mapped to %s, want %s", fields[i].ColumnName, fields[i].Type, want)
+		}
+	}
+	if got := fields[0].GORMTag["type"]; len(got) != 1 || got[0] != "integer[][]" {
+		t.Errorf("unexpected type tag %v", got)
+	}
+}
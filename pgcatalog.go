@@ -0,0 +1,146 @@
+package main
+
+import (
+	"log"
+
+	"github.com/dan-sherwin/gormdb2struct/pgtypes"
+	"gorm.io/gorm"
+)
+
+// pgForeignKeys returns the foreign key constraints declared in schemas. Column lists are
+// returned in constraint order so composite keys pair up column by column.
+func pgForeignKeys(db *gorm.DB, schemas []string) []foreignKey {
+	rows := []struct {
+		Name       string
+		Schema     string
+		Table      string
+		Columns    pgtypes.StringArray
+		RefSchema  string
+		RefTable   string
+		RefColumns pgtypes.StringArray
+	}{}
+	err := db.Raw(`SELECT con.conname AS name,
+       n.nspname AS schema,
+       c.relname AS table,
+       array_agg(a.attname::text ORDER BY k.ord) AS columns,
+       rn.nspname AS ref_schema,
+       rc.relname AS ref_table,
+       array_agg(ra.attname::text ORDER BY k.ord) AS ref_columns
+FROM pg_catalog.pg_constraint con
+JOIN pg_catalog.pg_class c ON c.oid = con.conrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_catalog.pg_class rc ON rc.oid = con.confrelid
+JOIN pg_catalog.pg_namespace rn ON rn.oid = rc.relnamespace
+CROSS JOIN LATERAL unnest(con.conkey, con.confkey) WITH ORDINALITY AS k(attnum, refattnum, ord)
+JOIN pg_catalog.pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+JOIN pg_catalog.pg_attribute ra ON ra.attrelid = con.confrelid AND ra.attnum = k.refattnum
+WHERE con.contype = 'f' AND n.nspname IN ?
+GROUP BY con.oid, con.conname, n.nspname, c.relname, rn.nspname, rc.relname
+ORDER BY n.nspname, c.relname, con.conname`, schemas).Scan(&rows).Error
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	fks := make([]foreignKey, 0, len(rows))
+	for _, r := range rows {
+		fks = append(fks, foreignKey{
+			Name:       r.Name,
+			Table:      pgTable{Schema: r.Schema, Name: r.Table}.QualifiedName(),
+			Columns:    r.Columns,
+			RefTable:   pgTable{Schema: r.RefSchema, Name: r.RefTable}.QualifiedName(),
+			RefColumns: r.RefColumns,
+		})
+	}
+	return fks
+}
+
+// pgUniqueKeys returns, per table, the column sets covered by a primary key or a unique index.
+// Partial and expression indexes are ignored since they do not make the columns unique, and
+// INCLUDE columns are not part of the key.
+func pgUniqueKeys(db *gorm.DB, schemas []string) map[string][][]string {
+	rows := []struct {
+		Schema  string
+		Table   string
+		Columns pgtypes.StringArray
+	}{}
+	err := db.Raw(`SELECT n.nspname AS schema,
+       c.relname AS table,
+       array_agg(a.attname::text ORDER BY k.ord) AS columns
+FROM pg_catalog.pg_index i
+JOIN pg_catalog.pg_class c ON c.oid = i.indrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+CROSS JOIN LATERAL unnest(i.indkey) WITH ORDINALITY AS k(attnum, ord)
+JOIN pg_catalog.pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = k.attnum
+WHERE i.indisunique AND k.ord <= i.indnkeyatts AND i.indpred IS NULL AND i.indexprs IS NULL AND n.nspname IN ?
+GROUP BY i.indexrelid, n.nspname, c.relname`, schemas).Scan(&rows).Error
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	keys := map[string][][]string{}
+	for _, r := range rows {
+		table := pgTable{Schema: r.Schema, Name: r.Table}.QualifiedName()
+		keys[table] = append(keys[table], r.Columns)
+	}
+	return keys
+}
+
+// pgComments returns the COMMENT ON text of the relations in schemas (tables, views, materialized
+// views) and of their columns, keyed by pgTable.QualifiedName and column name.
+func pgComments(db *gorm.DB, schemas []string) (tables map[string]string, columns map[string]map[string]string) {
+	rows := []struct {
+		Schema  string
+		Name    string
+		Column  string
+		Comment string
+	}{}
+	err := db.Raw(`SELECT n.nspname AS schema, c.relname AS name, coalesce(a.attname, '') AS column, d.description AS comment
+FROM pg_catalog.pg_description d
+JOIN pg_catalog.pg_class c ON c.oid = d.objoid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+LEFT JOIN pg_catalog.pg_attribute a ON a.attrelid = c.oid AND a.attnum = d.objsubid AND d.objsubid > 0
+WHERE d.classoid = 'pg_catalog.pg_class'::regclass AND c.relkind IN ('r', 'p', 'v', 'm', 'f') AND n.nspname IN ?`, schemas).Scan(&rows).Error
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	tables = map[string]string{}
+	columns = map[string]map[string]string{}
+	for _, r := range rows {
+		table := pgTable{Schema: r.Schema, Name: r.Name}.QualifiedName()
+		if r.Column == "" {
+			tables[table] = r.Comment
+			continue
+		}
+		if columns[table] == nil {
+			columns[table] = map[string]string{}
+		}
+		columns[table][r.Column] = r.Comment
+	}
+	return tables, columns
+}
+
+// pgArrayDimensions returns the declared number of dimensions (attndims) of every array column
+// with more than one, by qualified table name and column.
+func pgArrayDimensions(db *gorm.DB, schemas []string) map[string]map[string]int {
+	rows := []struct {
+		Schema string
+		Name   string
+		Column string
+		Dims   int
+	}{}
+	err := db.Raw(`SELECT n.nspname AS schema, c.relname AS name, a.attname AS column, a.attndims AS dims
+FROM pg_catalog.pg_attribute a
+JOIN pg_catalog.pg_class c ON c.oid = a.attrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE a.attnum > 0 AND NOT a.attisdropped AND a.attndims > 1 AND c.relkind IN ('r', 'p', 'v', 'm', 'f') AND n.nspname IN ?`, schemas).Scan(&rows).Error
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	dims := map[string]map[string]int{}
+	for _, r := range rows {
+		table := pgTable{Schema: r.Schema, Name: r.Name}.QualifiedName()
+		if dims[table] == nil {
+			dims[table] = map[string]int{}
+		}
+		dims[table][r.Column] = r.Dims
+	}
+	return dims
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"gorm.io/gorm"
+)
+
+// pgComposite is a standalone PostgreSQL composite type (CREATE TYPE ... AS (...)) and the Go
+// struct generated for it.
+type pgComposite struct {
+	pgUserType
+	Attributes []pgCompositeAttribute
+	resolved   bool
+}
+
+type pgCompositeAttribute struct {
+	Name      string
+	UdtName   string // udt name of the attribute type, or of a domain's base type
+	SQLType   string // format_type() of the attribute
+	FieldName string
+	GoType    string
+	JSONName  string
+}
+
+type pgComposites = pgUserTypes[*pgComposite]
+
+// loadPgComposites reads the attributes of every standalone composite type. Row types of tables
+// and views are not included.
+func loadPgComposites(db *gorm.DB) pgComposites {
+	rows := []struct {
+		Schema   string
+		Name     string
+		SQLName  string
+		Attr     string
+		UdtName  string
+		AttrType string
+	}{}
+	err := db.Raw(`SELECT n.nspname AS schema,
+       t.typname AS name,
+       format_type(t.oid, NULL) AS sql_name,
+       a.attname AS attr,
+       bt.typname AS udt_name,
+       format_type(a.atttypid, a.atttypmod) AS attr_type
+FROM pg_catalog.pg_type t
+JOIN pg_catalog.pg_class c ON c.oid = t.typrelid AND c.relkind = 'c'
+JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+JOIN pg_catalog.pg_attribute a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+JOIN pg_catalog.pg_type at ON at.oid = a.atttypid
+JOIN pg_catalog.pg_type bt ON bt.oid = CASE WHEN at.typtype = 'd' THEN at.typbasetype ELSE at.oid END
+WHERE t.typtype = 'c'
+ORDER BY n.nspname, t.typname, a.attnum`).Scan(&rows).Error
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	composites := pgComposites{}
+	for _, r := range rows {
+		c, ok := composites[r.SQLName]
+		if !ok {
+			c = &pgComposite{pgUserType: pgUserType{Schema: r.Schema, Name: r.Name, SQLName: r.SQLName}}
+			composites[r.SQLName] = c
+		}
+		c.Attributes = append(c.Attributes, pgCompositeAttribute{
+			Name:      r.Attr,
+			UdtName:   r.UdtName,
+			SQLType:   r.AttrType,
+			FieldName: goFieldName(r.Attr),
+			JSONName:  strcase.ToLowerCamel(r.Attr),
+		})
+	}
+	return composites
+}
+
+// resolvePgComposites maps the attributes of every used composite to Go types through dtMaps.
+// Attributes can themselves be composites or enums, which the DataTypeMap entries mark as used,
+// so this repeats until no new composite is reached. Attributes are nullable, hence pointers.
+func resolvePgComposites(composites pgComposites, dtMaps map[string]func(gorm.ColumnType) string) {
+	for changed := true; changed; {
+		changed = false
+		for _, c := range composites {
+			if !c.Used || c.resolved {
+				continue
+			}
+			for i, a := range c.Attributes {
+				goType := pgGoType(dtMaps, pgColumnType(a.Name, a.UdtName, a.SQLType))
+				if !strings.HasPrefix(goType, "*") {
+					goType = "*" + goType
+				}
+				c.Attributes[i].GoType = goType
+			}
+			c.resolved = true
+			changed = true
+		}
+	}
+}
+
+// generatePgComposites writes the Go structs of the composites referenced by generated models,
+// directly or through other composites. It must run before generatePgEnums, since composite
+// attributes can mark further enums as used.
+func generatePgComposites(cfg ConversionConfig, composites pgComposites, dtMaps map[string]func(gorm.ColumnType) string) {
+	resolvePgComposites(composites, dtMaps)
+	used := []*pgComposite{}
+	for _, c := range composites {
+		if c.Used {
+			used = append(used, c)
+		}
+	}
+	if len(used) == 0 {
+		return
+	}
+	sort.Slice(used, func(i, j int) bool { return used[i].TypeName < used[j].TypeName })
+	writeModelsFile(cfg.OutPath, "composites.gen.go", pgCompositesTemplate, struct {
+		Composites []*pgComposite
+		Imports    []string
+	}{used, modelImportPaths(cfg)})
+}
+
+var pgCompositesTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+// Go structs for the PostgreSQL composite types used by the models in this package.
+
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	{{- range .Imports}}
+	"{{.}}"
+	{{- end}}
+)
+{{range .Composites}}{{$t := .TypeName}}{{$n := len .Attributes}}
+// {{$t}} is the PostgreSQL composite type {{.SQLName}}.
+type {{$t}} struct {
+{{- range .Attributes}}
+	{{.FieldName}} {{.GoType}} ` + "`" + `json:"{{.JSONName}}"` + "`" + `
+{{- end}}
+}
+
+// Scan implements sql.Scanner by parsing a row literal of {{.SQLName}}.
+func (c *{{$t}}) Scan(src interface{}) error {
+	var text string
+	switch s := src.(type) {
+	case string:
+		text = s
+	case []byte:
+		text = string(s)
+	default:
+		return fmt.Errorf("cannot scan type %T into {{$t}}", src)
+	}
+	fields, err := pgtypes.ParseRecord(text)
+	if err != nil {
+		return err
+	}
+	if len(fields) != {{$n}} {
+		return fmt.Errorf("{{.SQLName}}: expected {{$n}} fields, got %d", len(fields))
+	}
+	var out {{$t}}
+{{- range $i, $a := .Attributes}}
+	if err := pgtypes.ScanRecordField(&out.{{$a.FieldName}}, fields[{{$i}}]); err != nil {
+		return fmt.Errorf("{{$a.Name}}: %w", err)
+	}
+{{- end}}
+	*c = out
+	return nil
+}
+
+// Value implements driver.Valuer by formatting c as a row literal of {{.SQLName}}.
+func (c {{$t}}) Value() (driver.Value, error) {
+	fields := make([]*string, {{$n}})
+	var err error
+{{- range $i, $a := .Attributes}}
+	if fields[{{$i}}], err = pgtypes.FormatRecordField(c.{{$a.FieldName}}); err != nil {
+		return nil, fmt.Errorf("{{$a.Name}}: %w", err)
+	}
+{{- end}}
+	return pgtypes.FormatRecord(fields), nil
+}
+{{if .UsedArr}}
+// {{$t}}Array is a PostgreSQL {{.SQLName}}[] column. NULL elements are rejected.
+type {{$t}}Array []{{$t}}
+
+// Scan implements sql.Scanner.
+func (a *{{$t}}Array) Scan(src interface{}) error {
+	var text string
+	switch s := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case string:
+		text = s
+	case []byte:
+		text = string(s)
+	default:
+		return fmt.Errorf("cannot scan type %T into {{$t}}Array", src)
+	}
+	elems, err := pgtypes.ParseArray(text)
+	if err != nil {
+		return err
+	}
+	out := make({{$t}}Array, len(elems))
+	for i, e := range elems {
+		if e == nil {
+			return fmt.Errorf("{{.SQLName}}[]: NULL element at index %d", i)
+		}
+		if err := out[i].Scan(*e); err != nil {
+			return err
+		}
+	}
+	*a = out
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (a {{$t}}Array) Value() (driver.Value, error) {
+	elems := make([]*string, len(a))
+	for i, c := range a {
+		v, err := c.Value()
+		if err != nil {
+			return nil, err
+		}
+		s := v.(string)
+		elems[i] = &s
+	}
+	return pgtypes.FormatArray(elems), nil
+}
+{{end}}{{end}}`
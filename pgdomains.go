@@ -0,0 +1,873 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dan-sherwin/gormdb2struct/pgtypes"
+	"github.com/iancoleman/strcase"
+	"gorm.io/gorm"
+)
+
+// pgDomain is a PostgreSQL domain and, with GenerateDomainTypes, the named Go type generated
+// for it.
+type pgDomain struct {
+	pgUserType
+	BaseUdt    string // typname of the base type, as reported for columns of the domain
+	BaseType   string // format_type() of the base type
+	Checks     []string
+	BaseDomain *pgDomain // set when the domain is defined over another domain
+
+	GoBase     string          // underlying Go type of the generated type
+	Validation []pgDomainCheck // translated CHECK constraints, including those of base domains
+	Unchecked  []string        // CHECK constraints Validate cannot verify
+}
+
+type pgDomainCheck struct {
+	Constraint string
+	Expr       string // Go condition over d that holds when the constraint is satisfied
+	Patterns   []pgDomainPattern
+}
+
+// pgDomainPattern is a package-level regexp used by a translated check.
+type pgDomainPattern struct {
+	Name   string
+	Regexp string
+}
+
+type pgDomains = pgUserTypes[*pgDomain]
+
+// loadPgDomains reads every domain with its base type and CHECK constraints.
+func loadPgDomains(db *gorm.DB) pgDomains {
+	rows := []struct {
+		Schema   string
+		Name     string
+		SQLName  string
+		BaseUdt  string
+		BaseType string
+		Checks   string
+	}{}
+	err := db.Raw(`SELECT n.nspname AS schema,
+       t.typname AS name,
+       format_type(t.oid, NULL) AS sql_name,
+       bt.typname AS base_udt,
+       format_type(t.typbasetype, t.typtypmod) AS base_type,
+       coalesce((SELECT json_agg(pg_get_constraintdef(c.oid) ORDER BY c.conname)
+                 FROM pg_catalog.pg_constraint c
+                 WHERE c.contypid = t.oid AND c.contype = 'c'), '[]')::text AS checks
+FROM pg_catalog.pg_type t
+JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+JOIN pg_catalog.pg_type bt ON bt.oid = t.typbasetype
+WHERE t.typtype = 'd' AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+ORDER BY n.nspname, t.typname`).Scan(&rows).Error
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	domains := pgDomains{}
+	for _, r := range rows {
+		d := &pgDomain{pgUserType: pgUserType{Schema: r.Schema, Name: r.Name, SQLName: r.SQLName}, BaseUdt: r.BaseUdt, BaseType: r.BaseType}
+		if err := json.Unmarshal([]byte(r.Checks), &d.Checks); err != nil {
+			log.Fatalf("domain %s: %v", r.SQLName, err)
+		}
+		domains[d.SQLName] = d
+	}
+	for _, d := range domains {
+		d.BaseDomain = domains[d.BaseType]
+	}
+	return domains
+}
+
+// pgDomainResolver maps domain columns to Go types. Columns of a domain are reported with the
+// domain's base type name, so every base type gets a DataTypeMap entry that recognises the
+// domain from ColumnType() and otherwise behaves as before.
+type pgDomainResolver struct {
+	domains  pgDomains
+	typeMap  map[string]string // DomainTypeMap
+	generate bool              // GenerateDomainTypes
+	base     map[string]func(gorm.ColumnType) string
+	skipped  map[*pgDomain]bool // domains already warned about
+}
+
+// register wraps the DataTypeMap entries of the domains' base types. It must run last, so that
+// a domain over an enum, composite or TypeMap'ed type resolves through those entries.
+func (r *pgDomainResolver) register(dtMaps map[string]func(gorm.ColumnType) string) {
+	r.base = make(map[string]func(gorm.ColumnType) string, len(dtMaps))
+	for k, v := range dtMaps {
+		r.base[k] = v
+	}
+	for _, d := range r.domains {
+		prev := r.base[d.BaseUdt]
+		dtMaps[d.BaseUdt] = func(columnType gorm.ColumnType) string {
+			if ct, ok := columnType.ColumnType(); ok {
+				if match, ok := r.domains[ct]; ok {
+					return r.goType(match)
+				}
+			}
+			if prev != nil {
+				return prev(columnType)
+			}
+			if scanType := columnType.ScanType(); scanType != nil {
+				return scanType.String()
+			}
+			return "string"
+		}
+		// Arrays of a domain use the array mapping of the domain's root base type.
+		root := d
+		for root.BaseDomain != nil {
+			root = root.BaseDomain
+		}
+		if mapping, ok := r.base[root.BaseType+"[]"]; ok {
+			dtMaps[d.SQLName+"[]"] = mapping
+		}
+	}
+}
+
+// goType returns the Go type for a column of domain d: a DomainTypeMap entry, the generated
+// named type, or the Go type of the base type.
+func (r *pgDomainResolver) goType(d *pgDomain) string {
+	if goType, ok := r.typeMap[d.SQLName]; ok {
+		return goType
+	}
+	if goType, ok := r.typeMap[d.Name]; ok {
+		return goType
+	}
+	if r.generate {
+		base := r.rootGoType(d)
+		if isBasicGoType(base) || base == "pgtypes.Decimal" {
+			if !d.Used {
+				r.prepare(d, base)
+			}
+			return d.TypeName
+		}
+		if r.skipped == nil {
+			r.skipped = map[*pgDomain]bool{}
+		}
+		if !r.skipped[d] {
+			r.skipped[d] = true
+			log.Printf("warning: domain %s: no named type is generated for base type %s; using %s", d.SQLName, d.BaseType, base)
+		}
+	}
+	if d.BaseDomain != nil {
+		return r.goType(d.BaseDomain)
+	}
+	return r.rootGoType(d)
+}
+
+// rootGoType resolves the Go type of the non-domain type at the bottom of d's domain chain.
+func (r *pgDomainResolver) rootGoType(d *pgDomain) string {
+	for d.BaseDomain != nil {
+		d = d.BaseDomain
+	}
+	return pgGoType(r.base, pgColumnType(d.Name, d.BaseUdt, d.BaseType))
+}
+
+// prepare marks d as generated and translates its CHECK constraints and those of the domains
+// it is defined over.
+func (r *pgDomainResolver) prepare(d *pgDomain, goBase string) {
+	d.Used = true
+	d.GoBase = goBase
+	patterns := 0
+	patternName := func() string {
+		patterns++
+		return strcase.ToLowerCamel(d.TypeName) + "Pattern" + strconv.Itoa(patterns)
+	}
+	for c := d; c != nil; c = c.BaseDomain {
+		for _, check := range c.Checks {
+			translated, err := translateDomainCheck(check, goBase, patternName)
+			if err != nil {
+				log.Printf("warning: domain %s: %s is not verified by the generated Validate: %v", d.SQLName, check, err)
+				d.Unchecked = append(d.Unchecked, check)
+				continue
+			}
+			d.Validation = append(d.Validation, translated)
+		}
+	}
+}
+
+func isBasicGoType(t string) bool {
+	switch t {
+	case "string", "bool", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+		return true
+	}
+	return false
+}
+
+// generatePgDomains writes the named Go types of the domains used by generated models.
+func generatePgDomains(cfg ConversionConfig, domains pgDomains) {
+	used := []*pgDomain{}
+	for _, d := range domains {
+		if d.Used {
+			used = append(used, d)
+		}
+	}
+	if len(used) == 0 {
+		return
+	}
+	sort.Slice(used, func(i, j int) bool { return used[i].TypeName < used[j].TypeName })
+	type domain struct {
+		*pgDomain
+		DriverType string
+		Decimal    bool
+	}
+	data := make([]domain, 0, len(used))
+	for _, d := range used {
+		data = append(data, domain{pgDomain: d, DriverType: driverValueType(d.GoBase), Decimal: d.GoBase == "pgtypes.Decimal"})
+	}
+	writeModelsFile(cfg.OutPath, "domains.gen.go", pgDomainsTemplate, data)
+}
+
+// driverValueType is the driver.Value type a domain's Value returns for its Go base type.
+func driverValueType(goBase string) string {
+	switch {
+	case strings.HasPrefix(goBase, "int"), strings.HasPrefix(goBase, "uint"):
+		return "int64"
+	case strings.HasPrefix(goBase, "float"):
+		return "float64"
+	}
+	return goBase
+}
+
+var pgDomainsTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+// Go types for the PostgreSQL domains used by the models in this package.
+
+package models
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dan-sherwin/gormdb2struct/pgtypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+{{range .}}{{$t := .TypeName}}
+// {{$t}} is the PostgreSQL domain {{.SQLName}} over {{.BaseType}}.
+{{- range .Validation}}
+//   - {{.Constraint}}
+{{- end}}
+{{- range .Unchecked}}
+//   - {{.}} (not verified by Validate)
+{{- end}}
+type {{$t}} {{.GoBase}}
+{{range .Validation}}{{range .Patterns}}
+var {{.Name}} = regexp.MustCompile({{printf "%q" .Regexp}})
+{{end}}{{end}}
+// Validate reports whether d satisfies the CHECK constraints of {{.SQLName}}.
+func (d {{$t}}) Validate() error {
+{{- range .Validation}}
+	if !({{.Expr}}) {
+		return fmt.Errorf("{{$t}} %v violates %s", d, {{printf "%q" .Constraint}})
+	}
+{{- end}}
+	return nil
+}
+
+// Scan implements sql.Scanner.
+func (d *{{$t}}) Scan(src interface{}) error {
+	var v sql.Null[{{.GoBase}}]
+	if err := v.Scan(src); err != nil {
+		return fmt.Errorf("cannot scan into {{$t}}: %w", err)
+	}
+	if !v.Valid {
+		return fmt.Errorf("cannot scan NULL into {{$t}}")
+	}
+	*d = {{$t}}(v.V)
+	return nil
+}
+
+// Value implements driver.Valuer and rejects values that violate the domain's constraints.
+func (d {{$t}}) Value() (driver.Value, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+{{- if .Decimal}}
+	return pgtypes.Decimal(d).Value()
+{{- else}}
+	return {{.DriverType}}(d), nil
+{{- end}}
+}
+{{- if .Decimal}}
+
+func (d {{$t}}) String() string {
+	return pgtypes.Decimal(d).String()
+}
+
+// MarshalJSON implements json.Marshaler as pgtypes.Decimal does.
+func (d {{$t}}) MarshalJSON() ([]byte, error) {
+	return pgtypes.Decimal(d).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler as pgtypes.Decimal does.
+func (d *{{$t}}) UnmarshalJSON(data []byte) error {
+	return (*pgtypes.Decimal)(d).UnmarshalJSON(data)
+}
+
+func ({{$t}}) GormDataType() string {
+	return pgtypes.Decimal{}.GormDataType()
+}
+
+func ({{$t}}) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return pgtypes.Decimal{}.GormDBDataType(db, field)
+}
+{{- end}}
+{{end}}`
+
+// translateDomainCheck turns a CHECK constraint of a domain, as printed by pg_get_constraintdef
+// (e.g. CHECK ((VALUE > 0))), into a Go condition over the domain value d of Go type goBase.
+// Comparisons, AND/OR/NOT, = ANY (ARRAY[...]), regular expression and LIKE matches, IS NOT NULL
+// and the length, lower, upper and btrim functions are supported; anything else is an error.
+// Regular expressions become package-level variables named by patternName.
+func translateDomainCheck(check, goBase string, patternName func() string) (pgDomainCheck, error) {
+	translated := pgDomainCheck{Constraint: check}
+	s := strings.TrimSpace(check)
+	if !strings.HasPrefix(s, "CHECK") {
+		return translated, fmt.Errorf("not a CHECK constraint")
+	}
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(strings.TrimPrefix(s, "CHECK")), "NOT VALID"))
+	tokens, err := tokenizeCheck(s)
+	if err != nil {
+		return translated, err
+	}
+	p := &checkParser{tokens: tokens, goBase: goBase}
+	e, err := p.parseOr()
+	if err != nil {
+		return translated, err
+	}
+	if p.pos != len(p.tokens) {
+		return translated, fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	if e.kind != checkBool {
+		return translated, fmt.Errorf("constraint is not a boolean expression")
+	}
+	// Pattern names are only handed out once the whole check translated, so a failed check does
+	// not leave gaps in the numbering.
+	code := e.code
+	for i, re := range p.patterns {
+		name := patternName()
+		code = strings.ReplaceAll(code, patternPlaceholder(i), name)
+		translated.Patterns = append(translated.Patterns, pgDomainPattern{Name: name, Regexp: re})
+	}
+	translated.Expr = code
+	return translated, nil
+}
+
+type checkTokenKind int
+
+const (
+	tokIdent checkTokenKind = iota
+	tokNumber
+	tokString
+	tokSymbol
+)
+
+type checkToken struct {
+	kind checkTokenKind
+	text string // identifiers are upper-cased; strings are unquoted
+}
+
+func tokenizeCheck(s string) ([]checkToken, error) {
+	var tokens []checkToken
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '\'':
+			var b strings.Builder
+			i++
+			for {
+				if i >= len(s) {
+					return nil, fmt.Errorf("unterminated string literal")
+				}
+				if s[i] == '\'' {
+					if i+1 < len(s) && s[i+1] == '\'' {
+						b.WriteByte('\'')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				b.WriteByte(s[i])
+				i++
+			}
+			tokens = append(tokens, checkToken{tokString, b.String()})
+		case c == '"':
+			j := strings.IndexByte(s[i+1:], '"')
+			if j < 0 {
+				return nil, fmt.Errorf("unterminated quoted identifier")
+			}
+			tokens = append(tokens, checkToken{tokIdent, s[i+1 : i+1+j]})
+			i += j + 2
+		case c >= '0' && c <= '9' || c == '.' && i+1 < len(s) && s[i+1] >= '0' && s[i+1] <= '9':
+			j := i
+			for j < len(s) && (s[j] >= '0' && s[j] <= '9' || s[j] == '.' || s[j] == 'e' || s[j] == 'E' ||
+				(s[j] == '-' || s[j] == '+') && (s[j-1] == 'e' || s[j-1] == 'E')) {
+				j++
+			}
+			tokens = append(tokens, checkToken{tokNumber, s[i:j]})
+			i = j
+		case c == '_' || unicode.IsLetter(rune(c)):
+			j := i
+			for j < len(s) && (s[j] == '_' || s[j] == '.' || unicode.IsLetter(rune(s[j])) || s[j] >= '0' && s[j] <= '9') {
+				j++
+			}
+			tokens = append(tokens, checkToken{tokIdent, strings.ToUpper(s[i:j])})
+			i = j
+		default:
+			for _, sym := range []string{"!~~*", "!~~", "~~*", "!~*", "::", "<>", "!=", "<=", ">=", "~~", "!~", "~*", "(", ")", "[", "]", ",", "=", "<", ">", "~", "-"} {
+				if strings.HasPrefix(s[i:], sym) {
+					tokens = append(tokens, checkToken{tokSymbol, sym})
+					i += len(sym)
+					goto next
+				}
+			}
+			return nil, fmt.Errorf("unsupported character %q", c)
+		next:
+		}
+	}
+	return tokens, nil
+}
+
+type checkKind int
+
+const (
+	checkBool checkKind = iota
+	checkNumber
+	checkString
+)
+
+type checkExpr struct {
+	code    string
+	kind    checkKind
+	goType  string  // Go type of a number: int64, uint64, float64 or decimal, or "" for a constant
+	literal *string // raw value of a string literal operand
+}
+
+type checkParser struct {
+	tokens   []checkToken
+	pos      int
+	goBase   string
+	patterns []string
+}
+
+func patternPlaceholder(i int) string {
+	return "\x00pattern" + strconv.Itoa(i) + "\x00"
+}
+
+func (p *checkParser) peek() (checkToken, bool) {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos], true
+	}
+	return checkToken{}, false
+}
+
+func (p *checkParser) accept(kind checkTokenKind, text string) bool {
+	if t, ok := p.peek(); ok && t.kind == kind && t.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *checkParser) expect(kind checkTokenKind, text string) error {
+	if !p.accept(kind, text) {
+		if t, ok := p.peek(); ok {
+			return fmt.Errorf("expected %q, found %q", text, t.text)
+		}
+		return fmt.Errorf("expected %q at end of constraint", text)
+	}
+	return nil
+}
+
+func (p *checkParser) parseOr() (checkExpr, error) {
+	return p.parseBinary("OR", "||", p.parseAnd)
+}
+
+func (p *checkParser) parseAnd() (checkExpr, error) {
+	return p.parseBinary("AND", "&&", p.parseNot)
+}
+
+func (p *checkParser) parseBinary(keyword, op string, next func() (checkExpr, error)) (checkExpr, error) {
+	l, err := next()
+	if err != nil {
+		return l, err
+	}
+	for p.accept(tokIdent, keyword) {
+		r, err := next()
+		if err != nil {
+			return r, err
+		}
+		if l.kind != checkBool || r.kind != checkBool {
+			return l, fmt.Errorf("%s of non-boolean operands", keyword)
+		}
+		l = checkExpr{code: "(" + l.code + " " + op + " " + r.code + ")", kind: checkBool}
+	}
+	return l, nil
+}
+
+func (p *checkParser) parseNot() (checkExpr, error) {
+	if p.accept(tokIdent, "NOT") {
+		e, err := p.parseNot()
+		if err != nil {
+			return e, err
+		}
+		if e.kind != checkBool {
+			return e, fmt.Errorf("NOT of a non-boolean operand")
+		}
+		return checkExpr{code: "!" + e.code, kind: checkBool}, nil
+	}
+	return p.parseComparison()
+}
+
+var checkComparisons = map[string]string{"=": "==", "<>": "!=", "!=": "!=", "<": "<", "<=": "<=", ">": ">", ">=": ">="}
+
+func (p *checkParser) parseComparison() (checkExpr, error) {
+	l, err := p.parseOperand()
+	if err != nil {
+		return l, err
+	}
+	t, ok := p.peek()
+	if !ok {
+		return l, nil
+	}
+	switch {
+	case t.kind == tokSymbol && checkComparisons[t.text] != "":
+		p.pos++
+		op := checkComparisons[t.text]
+		if p.accept(tokIdent, "ANY") || p.accept(tokIdent, "ALL") {
+			return p.parseArrayComparison(l, op, p.tokens[p.pos-1].text == "ALL")
+		}
+		r, err := p.parseOperand()
+		if err != nil {
+			return r, err
+		}
+		code, err := compareCheckOperands(l, op, r)
+		return checkExpr{code: code, kind: checkBool}, err
+	case t.kind == tokSymbol && strings.Contains(t.text, "~"):
+		p.pos++
+		r, err := p.parseOperand()
+		if err != nil {
+			return r, err
+		}
+		if l.kind != checkString || r.literal == nil {
+			return l, fmt.Errorf("%s needs a text operand and a literal pattern", t.text)
+		}
+		pattern, flags := *r.literal, ""
+		if strings.Contains(t.text, "~~") {
+			pattern = likeToRegexp(pattern)
+		} else {
+			if err := checkPgRegexp(pattern); err != nil {
+				return l, fmt.Errorf("pattern %q: %v", *r.literal, err)
+			}
+			flags = "s" // '.' matches a newline in PostgreSQL
+		}
+		if strings.HasSuffix(t.text, "*") {
+			flags = "i" + flags
+		}
+		if flags != "" {
+			pattern = "(?" + flags + ")" + pattern
+		}
+		// Patterns RE2 cannot compile (backreferences, lookaround, \m, [[:<:]], ...) leave the
+		// check unverified rather than generate a MustCompile that panics.
+		if _, err := regexp.Compile(pattern); err != nil {
+			return l, fmt.Errorf("pattern %q: %v", *r.literal, err)
+		}
+		code := patternPlaceholder(len(p.patterns)) + ".MatchString(" + l.code + ")"
+		p.patterns = append(p.patterns, pattern)
+		if strings.HasPrefix(t.text, "!") {
+			code = "!" + code
+		}
+		return checkExpr{code: code, kind: checkBool}, nil
+	case t.kind == tokIdent && t.text == "IS":
+		p.pos++
+		if p.accept(tokIdent, "NOT") && p.accept(tokIdent, "NULL") {
+			return checkExpr{code: "true", kind: checkBool}, nil
+		}
+		return l, fmt.Errorf("unsupported IS test")
+	}
+	return l, nil
+}
+
+func (p *checkParser) parseArrayComparison(l checkExpr, op string, all bool) (checkExpr, error) {
+	if err := p.expect(tokSymbol, "("); err != nil {
+		return l, err
+	}
+	if err := p.expect(tokIdent, "ARRAY"); err != nil {
+		return l, err
+	}
+	if err := p.expect(tokSymbol, "["); err != nil {
+		return l, err
+	}
+	var parts []string
+	for {
+		r, err := p.parseOperand()
+		if err != nil {
+			return r, err
+		}
+		part, err := compareCheckOperands(l, op, r)
+		if err != nil {
+			return l, err
+		}
+		parts = append(parts, part)
+		if !p.accept(tokSymbol, ",") {
+			break
+		}
+	}
+	if err := p.expect(tokSymbol, "]"); err != nil {
+		return l, err
+	}
+	p.skipCast()
+	if err := p.expect(tokSymbol, ")"); err != nil {
+		return l, err
+	}
+	join := " || "
+	if all {
+		join = " && "
+	}
+	return checkExpr{code: "(" + strings.Join(parts, join) + ")", kind: checkBool}, nil
+}
+
+// compareCheckOperands returns the Go condition l op r. Decimals are compared with Cmp.
+func compareCheckOperands(l checkExpr, op string, r checkExpr) (string, error) {
+	l2, r2 := coerceCheckOperands(l, r)
+	if l2.kind != r2.kind || l2.kind == checkBool && op != "==" && op != "!=" ||
+		(l2.goType == "decimal") != (r2.goType == "decimal") {
+		return "", fmt.Errorf("cannot compare %s with %s", l.code, r.code)
+	}
+	if l2.goType == "decimal" {
+		return "(" + l2.code + ".Cmp(" + r2.code + ") " + op + " 0)", nil
+	}
+	return "(" + l2.code + " " + op + " " + r2.code + ")", nil
+}
+
+// coerceCheckOperands turns a string literal compared with a number into a number, as in
+// VALUE > '0'::numeric, and brings two numbers to a common Go type. Integers are compared as
+// integers, so that bounds above 2^53 stay exact, unless the other operand is a fraction or a
+// constant out of range. Constants compared with a decimal become decimals.
+func coerceCheckOperands(l, r checkExpr) (checkExpr, checkExpr) {
+	toNumber := func(e checkExpr) checkExpr {
+		if e.literal != nil {
+			if _, err := strconv.ParseFloat(*e.literal, 64); err == nil {
+				return checkExpr{code: *e.literal, kind: checkNumber}
+			}
+		}
+		return e
+	}
+	if l.kind == checkNumber && r.kind == checkString {
+		r = toNumber(r)
+	} else if r.kind == checkNumber && l.kind == checkString {
+		l = toNumber(l)
+	}
+	toDecimal := func(e checkExpr) checkExpr {
+		if e.kind != checkNumber || e.goType != "" {
+			return e
+		}
+		if _, err := pgtypes.ParseDecimal(e.code); err != nil {
+			return e
+		}
+		return checkExpr{code: "pgtypes.MustParseDecimal(" + strconv.Quote(e.code) + ")", kind: checkNumber, goType: "decimal"}
+	}
+	switch {
+	case l.goType == "decimal":
+		r = toDecimal(r)
+	case r.goType == "decimal":
+		l = toDecimal(l)
+	case l.kind == checkNumber && r.kind == checkNumber && l.goType != r.goType:
+		l, r = widenCheckNumber(l, r), widenCheckNumber(r, l)
+	}
+	return l, r
+}
+
+// widenCheckNumber converts an integer operand e to float64 when other is not an integer of
+// the same type or a constant it can hold.
+func widenCheckNumber(e, other checkExpr) checkExpr {
+	if e.goType != "int64" && e.goType != "uint64" {
+		return e
+	}
+	if other.goType == "" {
+		var err error
+		if e.goType == "int64" {
+			_, err = strconv.ParseInt(other.code, 10, 64)
+		} else {
+			_, err = strconv.ParseUint(other.code, 10, 64)
+		}
+		if err == nil {
+			return e
+		}
+	}
+	return checkExpr{code: "float64(" + e.code + ")", kind: checkNumber, goType: "float64"}
+}
+
+var checkFunctions = map[string]struct {
+	code string
+	arg  checkKind
+	kind checkKind
+}{
+	"CHAR_LENGTH":      {"float64(utf8.RuneCountInString(%s))", checkString, checkNumber},
+	"CHARACTER_LENGTH": {"float64(utf8.RuneCountInString(%s))", checkString, checkNumber},
+	"LENGTH":           {"float64(utf8.RuneCountInString(%s))", checkString, checkNumber},
+	"OCTET_LENGTH":     {"float64(len(%s))", checkString, checkNumber},
+	"LOWER":            {"strings.ToLower(%s)", checkString, checkString},
+	"UPPER":            {"strings.ToUpper(%s)", checkString, checkString},
+	"BTRIM":            {"strings.TrimSpace(%s)", checkString, checkString},
+}
+
+func (p *checkParser) parseOperand() (checkExpr, error) {
+	t, ok := p.peek()
+	if !ok {
+		return checkExpr{}, fmt.Errorf("unexpected end of constraint")
+	}
+	p.pos++
+	var e checkExpr
+	switch {
+	case t.kind == tokSymbol && t.text == "(":
+		inner, err := p.parseOr()
+		if err != nil {
+			return inner, err
+		}
+		if err := p.expect(tokSymbol, ")"); err != nil {
+			return inner, err
+		}
+		e = inner
+	case t.kind == tokSymbol && t.text == "-":
+		n, err := p.parseOperand()
+		if err != nil || n.kind != checkNumber {
+			return n, fmt.Errorf("unsupported negation")
+		}
+		e = checkExpr{code: "-" + n.code, kind: checkNumber, goType: n.goType}
+		if n.goType == "decimal" {
+			e.code = n.code + ".Neg()"
+		}
+	case t.kind == tokNumber:
+		if _, err := strconv.ParseFloat(t.text, 64); err != nil {
+			return e, fmt.Errorf("invalid number %s", t.text)
+		}
+		e = checkExpr{code: t.text, kind: checkNumber}
+	case t.kind == tokString:
+		lit := t.text
+		e = checkExpr{code: strconv.Quote(lit), kind: checkString, literal: &lit}
+	case t.kind == tokIdent && t.text == "VALUE":
+		switch {
+		case p.goBase == "string":
+			e = checkExpr{code: "string(d)", kind: checkString}
+		case p.goBase == "bool":
+			e = checkExpr{code: "bool(d)", kind: checkBool}
+		case p.goBase == "pgtypes.Decimal":
+			e = checkExpr{code: "pgtypes.Decimal(d)", kind: checkNumber, goType: "decimal"}
+		default:
+			goType := "float64"
+			if strings.HasPrefix(p.goBase, "int") || strings.HasPrefix(p.goBase, "uint") {
+				goType = strings.TrimRight(p.goBase, "0123456789") + "64"
+			}
+			e = checkExpr{code: goType + "(d)", kind: checkNumber, goType: goType}
+		}
+	case t.kind == tokIdent && (t.text == "TRUE" || t.text == "FALSE"):
+		e = checkExpr{code: strings.ToLower(t.text), kind: checkBool}
+	case t.kind == tokIdent && p.accept(tokSymbol, "("):
+		fn, ok := checkFunctions[strings.TrimPrefix(t.text, "PG_CATALOG.")]
+		if !ok {
+			return e, fmt.Errorf("unsupported function %s", strings.ToLower(t.text))
+		}
+		arg, err := p.parseOperand()
+		if err != nil {
+			return arg, err
+		}
+		if arg.kind != fn.arg {
+			return arg, fmt.Errorf("unsupported argument to %s", strings.ToLower(t.text))
+		}
+		if err := p.expect(tokSymbol, ")"); err != nil {
+			return arg, err
+		}
+		e = checkExpr{code: fmt.Sprintf(fn.code, arg.code), kind: fn.kind}
+		if fn.kind == checkNumber {
+			e.goType = "float64"
+		}
+	default:
+		return e, fmt.Errorf("unsupported %q", t.text)
+	}
+	if cast := p.skipCast(); cast != "" && e.literal != nil && isNumericSQLType(cast) {
+		if _, err := strconv.ParseFloat(*e.literal, 64); err == nil {
+			e = checkExpr{code: *e.literal, kind: checkNumber}
+		}
+	}
+	return e, nil
+}
+
+// skipCast consumes a ::type suffix (possibly several words or an array type) and returns the
+// type name.
+func (p *checkParser) skipCast() string {
+	var cast string
+	for p.accept(tokSymbol, "::") {
+		words := []string{}
+		for {
+			t, ok := p.peek()
+			if !ok || t.kind != tokIdent || t.text == "AND" || t.text == "OR" || t.text == "NOT" || t.text == "IS" {
+				break
+			}
+			words = append(words, t.text)
+			p.pos++
+		}
+		for p.accept(tokSymbol, "[") && p.accept(tokSymbol, "]") {
+			words = append(words, "[]")
+		}
+		cast = strings.Join(words, " ")
+	}
+	return cast
+}
+
+func isNumericSQLType(t string) bool {
+	switch strings.TrimPrefix(t, "PG_CATALOG.") {
+	case "NUMERIC", "INTEGER", "BIGINT", "SMALLINT", "REAL", "DOUBLE PRECISION", "INT", "INT2", "INT4", "INT8", "FLOAT4", "FLOAT8", "DECIMAL":
+		return true
+	}
+	return false
+}
+
+// checkPgRegexp refuses the escapes that compile in Go but mean something else in a PostgreSQL
+// regular expression: \b and \B are a backspace and a backslash there, word boundaries in Go.
+func checkPgRegexp(pattern string) error {
+	for i := 0; i+1 < len(pattern); i++ {
+		if pattern[i] == '\\' {
+			i++
+			if pattern[i] == 'b' || pattern[i] == 'B' {
+				return fmt.Errorf("\\%c means something else in Go", pattern[i])
+			}
+		}
+	}
+	return nil
+}
+
+// likeToRegexp converts a LIKE pattern with the default backslash escape into an anchored
+// regular expression.
+func likeToRegexp(like string) string {
+	var b strings.Builder
+	b.WriteString("^(?s:")
+	for i := 0; i < len(like); i++ {
+		switch c := like[i]; c {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		case '\\':
+			if i+1 < len(like) {
+				i++
+				b.WriteString(regexp.QuoteMeta(like[i : i+1]))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString(")$")
+	return b.String()
+}
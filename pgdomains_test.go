@@ -0,0 +1,77 @@
+package main
+
+import "testing"
+
+func TestTranslateDomainCheck(t *testing.T) {
+	names := func() func() string {
+		n := 0
+		return func() string { n++; return "p" + string(rune('0'+n)) }
+	}
+	cases := []struct {
+		check, goBase, want string
+	}{
+		{"CHECK ((VALUE > 0))", "int32", "(int64(d) > 0)"},
+		{"CHECK ((VALUE < '9007199254740993'::bigint))", "int64", "(int64(d) < 9007199254740993)"},
+		{"CHECK ((VALUE >= 0.5))", "int64", "(float64(int64(d)) >= 0.5)"},
+		{"CHECK ((VALUE < 99999999999999999999))", "int64", "(float64(int64(d)) < 99999999999999999999)"},
+		{"CHECK ((NOT (VALUE = -1)))", "int16", "!(int64(d) == -1)"},
+		{"CHECK (((VALUE >= '0'::numeric) AND (VALUE <= 100.5)))", "float64", "((float64(d) >= 0) && (float64(d) <= 100.5))"},
+		{"CHECK ((char_length(VALUE) <= 64))", "string", "(float64(utf8.RuneCountInString(string(d))) <= 64)"},
+		{"CHECK ((VALUE ~* '^[a-z]+$'::text))", "string", "p1.MatchString(string(d))"},
+		{"CHECK ((NOT (VALUE ~~ 'tmp\\_%'::text)))", "string", "!p1.MatchString(string(d))"},
+		{"CHECK ((VALUE = ANY (ARRAY['low'::text, 'high'::text])))", "string", `((string(d) == "low") || (string(d) == "high"))`},
+		{"CHECK ((lower(VALUE) <> 'admin'::text))", "string", `(strings.ToLower(string(d)) != "admin")`},
+		{"CHECK ((VALUE IS NOT NULL))", "string", "true"},
+		{"CHECK ((VALUE = true))", "bool", "(bool(d) == true)"},
+		{"CHECK ((VALUE > (0)::numeric))", "pgtypes.Decimal", `(pgtypes.Decimal(d).Cmp(pgtypes.MustParseDecimal("0")) > 0)`},
+		{"CHECK ((VALUE <> ALL (ARRAY[-1, '2.5'::numeric])))", "pgtypes.Decimal",
+			`((pgtypes.Decimal(d).Cmp(pgtypes.MustParseDecimal("-1")) != 0) && (pgtypes.Decimal(d).Cmp(pgtypes.MustParseDecimal("2.5")) != 0))`},
+		{"CHECK ((- VALUE < 5))", "pgtypes.Decimal", `(pgtypes.Decimal(d).Neg().Cmp(pgtypes.MustParseDecimal("5")) < 0)`},
+	}
+	for _, c := range cases {
+		got, err := translateDomainCheck(c.check, c.goBase, names())
+		if err != nil {
+			t.Errorf("%s: %v", c.check, err)
+			continue
+		}
+		if got.Expr != c.want {
+			t.Errorf("%s:\n got %s\nwant %s", c.check, got.Expr, c.want)
+		}
+	}
+
+	got, _ := translateDomainCheck("CHECK ((VALUE ~* '^[a-z]+$'::text))", "string", names())
+	if len(got.Patterns) != 1 || got.Patterns[0].Regexp != "(?is)^[a-z]+$" {
+		t.Errorf("patterns: %+v", got.Patterns)
+	}
+	got, _ = translateDomainCheck("CHECK ((NOT (VALUE ~~ 'tmp\\_%'::text)))", "string", names())
+	if len(got.Patterns) != 1 || got.Patterns[0].Regexp != `^(?s:tmp_.*)$` {
+		t.Errorf("LIKE pattern: %+v", got.Patterns)
+	}
+
+	for _, check := range []string{
+		"CHECK ((VALUE > now()))",                    // unsupported function
+		"CHECK ((VALUE ~ '(?<=a)b'::text))",          // not valid RE2
+		"CHECK ((length(VALUE) > 0))",                // length of a number
+		"CHECK (((VALUE)::text ~ '^[0-9]+$'::text))", // cast of VALUE to text
+		"CHECK ((VALUE @> '[1,2)'::int4range))",      // unsupported operator
+		"CHECK ((NOT (VALUE > now())))",              // unsupported function under NOT
+	} {
+		if _, err := translateDomainCheck(check, "int32", names()); err == nil {
+			t.Errorf("%s: expected an error", check)
+		}
+	}
+	// Patterns Go would refuse, or read differently, leave the check unverified.
+	for _, check := range []string{
+		`CHECK ((VALUE ~ '\mword\M'::text))`,    // PostgreSQL word boundaries
+		"CHECK ((VALUE ~ '[[:<:]]word'::text))", // PostgreSQL word boundary class
+		`CHECK ((VALUE ~ '(a)\1'::text))`,       // backreference
+		`CHECK ((VALUE ~ '\bword'::text))`,      // backspace in PostgreSQL
+	} {
+		if _, err := translateDomainCheck(check, "string", names()); err == nil {
+			t.Errorf("%s: expected an error", check)
+		}
+	}
+	if _, err := translateDomainCheck("CHECK ((NOT (VALUE > now())))", "int32", names()); err == nil || err.Error() != "unsupported function now" {
+		t.Errorf("NOT should keep the error of its operand, got %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+	"strconv"
+	"unicode"
+
+	"github.com/iancoleman/strcase"
+	"gorm.io/gorm"
+)
+
+// pgEnum is a PostgreSQL enum type and the Go type generated for it.
+type pgEnum struct {
+	pgUserType
+	Labels []string
+	Consts []pgEnumConst
+}
+
+type pgEnumConst struct {
+	Name  string
+	Label string
+}
+
+type pgEnums = pgUserTypes[*pgEnum]
+
+// loadPgEnums reads all enum types and their labels (in sort order) from pg_enum.
+func loadPgEnums(db *gorm.DB) pgEnums {
+	rows := []struct {
+		Schema  string
+		Name    string
+		SQLName string
+		Labels  string
+	}{}
+	err := db.Raw(`SELECT n.nspname AS schema,
+       t.typname AS name,
+       format_type(t.oid, NULL) AS sql_name,
+       json_agg(e.enumlabel ORDER BY e.enumsortorder)::text AS labels
+FROM pg_catalog.pg_type t
+JOIN pg_catalog.pg_enum e ON e.enumtypid = t.oid
+JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+GROUP BY n.nspname, t.typname, t.oid
+ORDER BY n.nspname, t.typname`).Scan(&rows).Error
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	enums := pgEnums{}
+	for _, r := range rows {
+		e := &pgEnum{pgUserType: pgUserType{Schema: r.Schema, Name: r.Name, SQLName: r.SQLName}}
+		if err := json.Unmarshal([]byte(r.Labels), &e.Labels); err != nil {
+			log.Fatalf("enum %s: %v", r.SQLName, err)
+		}
+		enums[e.SQLName] = e
+	}
+	return enums
+}
+
+// pgEnumConsts names a constant per label (ticket_status 'in-progress' -> TicketStatusInProgress).
+// Labels that do not produce an identifier are numbered by position instead.
+func pgEnumConsts(typeName string, labels []string) []pgEnumConst {
+	taken := map[string]bool{}
+	consts := make([]pgEnumConst, 0, len(labels))
+	for i, label := range labels {
+		suffix := strcase.ToCamel(label)
+		valid := suffix != ""
+		for _, r := range suffix {
+			valid = valid && (r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r))
+		}
+		if !valid {
+			suffix = "Value" + strconv.Itoa(i+1)
+		}
+		name := typeName + suffix
+		for n := 2; taken[name]; n++ {
+			name = typeName + suffix + strconv.Itoa(n)
+		}
+		taken[name] = true
+		consts = append(consts, pgEnumConst{Name: name, Label: label})
+	}
+	return consts
+}
+
+// generatePgEnums writes the Go types of the enums referenced by generated models.
+func generatePgEnums(cfg ConversionConfig, enums pgEnums) {
+	used := []*pgEnum{}
+	hasArrays := false
+	for _, e := range enums {
+		if e.Used {
+			e.Consts = pgEnumConsts(e.TypeName, e.Labels)
+			used = append(used, e)
+			hasArrays = hasArrays || e.UsedArr
+		}
+	}
+	if len(used) == 0 {
+		return
+	}
+	sort.Slice(used, func(i, j int) bool { return used[i].TypeName < used[j].TypeName })
+	writeModelsFile(cfg.OutPath, "enums.gen.go", pgEnumsTemplate, struct {
+		Enums     []*pgEnum
+		HasArrays bool
+	}{used, hasArrays})
+}
+
+var pgEnumsTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+// Go types for the PostgreSQL enum types used by the models in this package.
+
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	{{- if .HasArrays}}
+
+	"github.com/dan-sherwin/gormdb2struct/pgtypes"
+	{{- end}}
+)
+{{range .Enums}}{{$t := .TypeName}}
+// {{$t}} is the PostgreSQL enum type {{.SQLName}}.
+type {{$t}} string
+
+const (
+{{- range .Consts}}
+	{{.Name}} {{$t}} = {{printf "%q" .Label}}
+{{- end}}
+)
+
+// Values returns every {{$t}} label in the enum's sort order.
+func ({{$t}}) Values() []{{$t}} {
+	return []{{$t}}{ {{- range $i, $c := .Consts}}{{if $i}}, {{end}}{{$c.Name}}{{end -}} }
+}
+
+// IsValid reports whether e is one of the labels of {{.SQLName}}.
+func (e {{$t}}) IsValid() bool {
+	switch e {
+	case {{range $i, $c := .Consts}}{{if $i}}, {{end}}{{$c.Name}}{{end}}:
+		return true
+	}
+	return false
+}
+
+func (e {{$t}}) String() string {
+	return string(e)
+}
+
+// Scan implements sql.Scanner and rejects labels unknown to {{$t}}.
+func (e *{{$t}}) Scan(src interface{}) error {
+	var v {{$t}}
+	switch s := src.(type) {
+	case string:
+		v = {{$t}}(s)
+	case []byte:
+		v = {{$t}}(s)
+	default:
+		return fmt.Errorf("cannot scan type %T into {{$t}}", src)
+	}
+	if !v.IsValid() {
+		return fmt.Errorf("invalid {{$t}} %q", string(v))
+	}
+	*e = v
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (e {{$t}}) Value() (driver.Value, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("invalid {{$t}} %q", string(e))
+	}
+	return string(e), nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The zero value, an unset column, marshals as
+// the empty string.
+func (e {{$t}}) MarshalText() ([]byte, error) {
+	if e != "" && !e.IsValid() {
+		return nil, fmt.Errorf("invalid {{$t}} %q", string(e))
+	}
+	return []byte(e), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The empty string unmarshals as the zero
+// value, mirroring MarshalText.
+func (e *{{$t}}) UnmarshalText(data []byte) error {
+	v := {{$t}}(data)
+	if v != "" && !v.IsValid() {
+		return fmt.Errorf("invalid {{$t}} %q", string(data))
+	}
+	*e = v
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e {{$t}}) MarshalJSON() ([]byte, error) {
+	text, err := e.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *{{$t}}) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return e.UnmarshalText([]byte(s))
+}
+{{if .UsedArr}}
+// {{$t}}Array is a PostgreSQL {{.SQLName}}[] column.
+type {{$t}}Array []{{$t}}
+
+// Scan implements sql.Scanner and rejects labels unknown to {{$t}}.
+func (a *{{$t}}Array) Scan(src interface{}) error {
+	var labels pgtypes.StringArray
+	if err := labels.Scan(src); err != nil {
+		return err
+	}
+	if labels == nil {
+		*a = nil
+		return nil
+	}
+	out := make({{$t}}Array, len(labels))
+	for i, label := range labels {
+		if err := out[i].Scan(label); err != nil {
+			return err
+		}
+	}
+	*a = out
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (a {{$t}}Array) Value() (driver.Value, error) {
+	labels := make(pgtypes.StringArray, len(a))
+	for i, e := range a {
+		if !e.IsValid() {
+			return nil, fmt.Errorf("invalid {{$t}} %q", string(e))
+		}
+		labels[i] = string(e)
+	}
+	return labels.Value()
+}
+{{end}}{{end}}`
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"log"
+	"regexp"
+	"strings"
+
+	"gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+// pgExtensionGoTypes maps the types of supported extensions, by extension and type name, to
+// their pgtypes Go type. The array form uses the type's named array (Go type + "Array").
+var pgExtensionGoTypes = map[string]map[string]string{
+	"hstore": {"hstore": "pgtypes.Hstore"},
+	"ltree":  {"ltree": "pgtypes.LTree"},
+	"citext": {"citext": "pgtypes.CIText"},
+	"vector": {"vector": "pgtypes.Vector"},
+	"postgis": {
+		"geometry":  "pgtypes.Geometry[pgtypes.Shape]",
+		"geography": "pgtypes.Geometry[pgtypes.Shape]",
+	},
+}
+
+// postgisShapes maps the geometry types of PostGIS typmods, in lower case, to pgtypes shapes.
+var postgisShapes = map[string]string{
+	"point":              "pgtypes.Point",
+	"linestring":         "pgtypes.LineString",
+	"polygon":            "pgtypes.Polygon",
+	"multipoint":         "pgtypes.MultiPoint",
+	"multilinestring":    "pgtypes.MultiLineString",
+	"multipolygon":       "pgtypes.MultiPolygon",
+	"geometrycollection": "pgtypes.GeometryCollection",
+}
+
+var postgisTypmodRegexp = regexp.MustCompile(`(?i)\((\w+?)(ZM|Z|M)?\s*(?:,\s*-?\d+)?\)$`)
+
+var pgVectorTypeRegexp = regexp.MustCompile(`^vector\((\d+)\)$`)
+
+// pgExtensionType is a type created by an installed extension.
+type pgExtensionType struct {
+	Extension string
+	Name      string
+	SQLName   string // format_type() of the type: schema-qualified when not on the search path
+}
+
+// loadPgExtensionTypes reads the types owned by the supported extensions. Extension types get a
+// new OID in every database, so they are found through their pg_depend membership instead.
+func loadPgExtensionTypes(db *gorm.DB) []pgExtensionType {
+	extensions := make([]string, 0, len(pgExtensionGoTypes))
+	for name := range pgExtensionGoTypes {
+		extensions = append(extensions, name)
+	}
+	types := []pgExtensionType{}
+	err := db.Raw(`SELECT e.extname AS extension, t.typname AS name, format_type(t.oid, NULL) AS sql_name
+FROM pg_catalog.pg_type t
+JOIN pg_catalog.pg_depend d ON d.classid = 'pg_catalog.pg_type'::regclass AND d.objid = t.oid AND d.deptype = 'e'
+JOIN pg_catalog.pg_extension e ON e.oid = d.refobjid
+WHERE e.extname IN ?
+ORDER BY e.extname, t.typname`, extensions).Scan(&types).Error
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	return types
+}
+
+// registerPgExtensionTypes adds DataTypeMap entries for the extension types and their arrays,
+// keyed like the postgres driver reports the columns (see pgUserTypes.register).
+func registerPgExtensionTypes(dtMaps map[string]func(gorm.ColumnType) string, types []pgExtensionType) {
+	for _, t := range types {
+		goType, ok := pgExtensionGoTypes[t.Extension][t.Name]
+		if !ok {
+			continue
+		}
+		if t.Extension == "postgis" {
+			// Arrays of PostGIS types are delimited by ':' rather than ',' and stay unmapped.
+			dtMaps[t.Name] = func(columnType gorm.ColumnType) string { return postgisGoType(columnType, goType) }
+			continue
+		}
+		dtMaps[t.Name] = func(gorm.ColumnType) string { return goType }
+		dtMaps[t.SQLName+"[]"] = func(gorm.ColumnType) string { return goType + "Array" }
+	}
+}
+
+// postgisGoType returns the Go type of a geometry or geography column from the shape in its
+// typmod, such as geometry(Point,4326) -> pgtypes.Geometry[pgtypes.Point], or fallback when it
+// has none. 3D and measured geometries (PointZ, ...) are not supported by pgtypes and stay
+// strings of hex-encoded EWKB.
+func postgisGoType(columnType gorm.ColumnType, fallback string) string {
+	ct, _ := columnType.ColumnType()
+	m := postgisTypmodRegexp.FindStringSubmatch(ct)
+	switch {
+	case m == nil:
+		return fallback
+	case m[2] != "":
+		return "string"
+	}
+	if shape, ok := postgisShapes[strings.ToLower(m[1])]; ok {
+		return "pgtypes.Geometry[" + shape + "]"
+	}
+	return fallback
+}
+
+// addVectorDimensionTags gives every pgtypes.Vector field of a vector(n) column a size tag of n
+// and the vector serializer, which checks the dimensions on Scan and Value.
+func addVectorDimensionTags(fields []gen.Field) {
+	for _, f := range fields {
+		if strings.TrimPrefix(f.Type, "*") != "pgtypes.Vector" || len(f.GORMTag["type"]) == 0 {
+			continue
+		}
+		if m := pgVectorTypeRegexp.FindStringSubmatch(f.GORMTag["type"][0]); m != nil {
+			f.GORMTag.Set("size", m[1])
+			f.GORMTag.Set("serializer", "vector")
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/dan-sherwin/gormdb2struct/pgtypes"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+func TestRegisterPgExtensionTypes(t *testing.T) {
+	dtMaps := pgtypes.DataTypeMap()
+	registerPgExtensionTypes(dtMaps, []pgExtensionType{
+		{Extension: "hstore", Name: "hstore", SQLName: "hstore"},
+		{Extension: "ltree", Name: "ltree", SQLName: "ltree"},
+		{Extension: "ltree", Name: "lquery", SQLName: "lquery"},
+		{Extension: "citext", Name: "citext", SQLName: "ext.citext"},
+		{Extension: "postgis", Name: "geometry", SQLName: "geometry"},
+		{Extension: "postgis", Name: "geography", SQLName: "geography"},
+	})
+	for _, c := range []struct{ udt, sqlType, want string }{
+		{"hstore", "hstore", "pgtypes.Hstore"},
+		{"_hstore", "hstore[]", "pgtypes.HstoreArray"},
+		{"ltree", "ltree", "pgtypes.LTree"},
+		{"_ltree", "ltree[]", "pgtypes.LTreeArray"},
+		{"citext", "ext.citext", "pgtypes.CIText"},
+		{"_citext", "ext.citext[]", "pgtypes.CITextArray"},
+		{"geometry", "geometry(Point,4326)", "pgtypes.Geometry[pgtypes.Point]"},
+		{"geometry", "geometry(MULTIPOLYGON)", "pgtypes.Geometry[pgtypes.MultiPolygon]"},
+		{"geography", "geography(LineString,4326)", "pgtypes.Geometry[pgtypes.LineString]"},
+		{"geometry", "geometry", "pgtypes.Geometry[pgtypes.Shape]"},
+		{"geometry", "geometry(Geometry,3857)", "pgtypes.Geometry[pgtypes.Shape]"},
+		{"geometry", "geometry(PointZ,4326)", "string"},
+		{"point", "point", "pgtypes.Point"},
+		{"_polygon", "polygon[]", "pgtypes.Array[pgtypes.Polygon]"},
+	} {
+		if got := pgGoType(dtMaps, pgColumnType("c", c.udt, c.sqlType)); got != c.want {
+			t.Errorf("%s mapped to %s, want %s", c.sqlType, got, c.want)
+		}
+	}
+	for _, name := range []string{"lquery", "geometry[]"} {
+		if _, ok := dtMaps[name]; ok {
+			t.Errorf("%s should not be mapped", name)
+		}
+	}
+}
+
+func TestAddVectorDimensionTags(t *testing.T) {
+	fields := []gen.Field{
+		{ColumnName: "embedding", Type: "*pgtypes.Vector", GORMTag: field.GormTag{"type": {"vector(1536)"}}},
+		{ColumnName: "any_dim", Type: "pgtypes.Vector", GORMTag: field.GormTag{"type": {"vector"}}},
+		{ColumnName: "name", Type: "string", GORMTag: field.GormTag{"type": {"vector(3)"}}},
+	}
+	addVectorDimensionTags(fields)
+	if got := fields[0].GORMTag; len(got["size"]) != 1 || got["size"][0] != "1536" || got["serializer"][0] != "vector" {
+		t.Errorf("unexpected tags %v", got)
+	}
+	for _, f := range fields[1:] {
+		if _, ok := f.GORMTag["serializer"]; ok {
+			t.Errorf("%s should not get the vector serializer", f.ColumnName)
+		}
+	}
+}
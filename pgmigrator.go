@@ -0,0 +1,109 @@
+package main
+
+import (
+	"database/sql"
+	"reflect"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+)
+
+// pgDialector wraps the postgres dialector used for introspection. information_schema.columns
+// does not list the columns of materialized views, so column lookups for the relations in
+// materializedViews are answered from pg_attribute instead. Nothing is ever created in the
+// database, which keeps generation working for read-only roles and read replicas.
+type pgDialector struct {
+	*postgres.Dialector
+	materializedViews map[string]pgTable // keyed by pgTable.QualifiedName()
+}
+
+func (d *pgDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return pgMigrator{
+		Migrator:          d.Dialector.Migrator(db).(postgres.Migrator),
+		materializedViews: d.materializedViews,
+	}
+}
+
+type pgMigrator struct {
+	postgres.Migrator
+	materializedViews map[string]pgTable
+}
+
+func (m pgMigrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
+	if name, ok := value.(string); ok {
+		if view, ok := m.materializedViews[name]; ok {
+			return m.materializedViewColumnTypes(view)
+		}
+	}
+	return m.Migrator.ColumnTypes(value)
+}
+
+// materializedViewColumnTypes reads column metadata for a materialized view straight from the
+// catalog. DataTypeValue mirrors what the postgres driver reports for tables: the udt name of
+// the column (or of a domain's base type), or the formatted type for arrays (e.g. "text[]").
+func (m pgMigrator) materializedViewColumnTypes(view pgTable) ([]gorm.ColumnType, error) {
+	rows := []struct {
+		Name       string
+		UdtName    string
+		ColumnType string
+		Nullable   bool
+		Comment    sql.NullString
+	}{}
+	err := m.DB.Raw(`SELECT a.attname AS name,
+       bt.typname AS udt_name,
+       format_type(a.atttypid, a.atttypmod) AS column_type,
+       NOT a.attnotnull AS nullable,
+       col_description(a.attrelid, a.attnum) AS comment
+FROM pg_catalog.pg_attribute a
+JOIN pg_catalog.pg_class c ON c.oid = a.attrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_catalog.pg_type t ON t.oid = a.atttypid
+JOIN pg_catalog.pg_type bt ON bt.oid = CASE WHEN t.typtype = 'd' THEN t.typbasetype ELSE t.oid END
+WHERE n.nspname = ? AND c.relname = ? AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY a.attnum`, view.Schema, view.Name).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	columnTypes := make([]gorm.ColumnType, 0, len(rows))
+	for _, r := range rows {
+		dataType := pgDataTypeName(r.UdtName, r.ColumnType)
+		columnTypes = append(columnTypes, &migrator.ColumnType{
+			NameValue:       sql.NullString{String: r.Name, Valid: true},
+			DataTypeValue:   sql.NullString{String: dataType, Valid: true},
+			ColumnTypeValue: sql.NullString{String: r.ColumnType, Valid: true},
+			NullableValue:   sql.NullBool{Bool: r.Nullable, Valid: true},
+			PrimaryKeyValue: sql.NullBool{Valid: true},
+			UniqueValue:     sql.NullBool{Valid: true},
+			CommentValue:    r.Comment,
+			ScanTypeValue:   pgScanType(r.UdtName),
+		})
+	}
+	return columnTypes, nil
+}
+
+// pgScanType returns the Go type pgx reports for a column of the given udt name, so that
+// columns without a DataTypeMap entry get the same type they would get on a table.
+func pgScanType(udtName string) reflect.Type {
+	switch udtName {
+	case "float8", "numeric":
+		return reflect.TypeOf(float64(0))
+	case "float4":
+		return reflect.TypeOf(float32(0))
+	case "int8":
+		return reflect.TypeOf(int64(0))
+	case "int4":
+		return reflect.TypeOf(int32(0))
+	case "int2":
+		return reflect.TypeOf(int16(0))
+	case "bool":
+		return reflect.TypeOf(false)
+	case "date", "timestamp", "timestamptz":
+		return reflect.TypeOf(time.Time{})
+	case "bytea":
+		return reflect.TypeOf([]byte(nil))
+	default:
+		return reflect.TypeOf("")
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gorm.io/gen"
+)
+
+// pgSearchModel is a generated model with tsvector columns, for which the query package gets
+// full-text search helpers.
+type pgSearchModel struct {
+	FileName        string
+	ModelStructName string
+	QueryStructName string
+	Columns         []pgSearchColumn
+}
+
+// pgSearchColumn is a tsvector column of a pgSearchModel and the name of its Search method.
+type pgSearchColumn struct {
+	Method     string
+	FieldName  string
+	ColumnName string
+}
+
+// Receiver returns the receiver name gen uses for the query struct.
+func (m pgSearchModel) Receiver() string {
+	return strings.ToLower(m.QueryStructName[:1])
+}
+
+// newPgSearchModel returns the search model of a generated model, or false when none of its
+// fields is a pgtypes.TSVector. A single column gets Search; with several, or when Search is
+// already a field of the query struct, each gets Search<Field>.
+func newPgSearchModel(fileName, modelStructName, queryStructName string, fields []gen.Field) (pgSearchModel, bool) {
+	m := pgSearchModel{FileName: fileName, ModelStructName: modelStructName, QueryStructName: queryStructName}
+	names := map[string]bool{}
+	for _, f := range fields {
+		names[f.Name] = true
+		if strings.TrimPrefix(f.Type, "*") == "pgtypes.TSVector" {
+			m.Columns = append(m.Columns, pgSearchColumn{FieldName: f.Name, ColumnName: f.ColumnName})
+		}
+	}
+	taken := func(method string) bool {
+		return names[method] || names[method+"TSQuery"] || names[method+"Query"]
+	}
+	columns := m.Columns[:0]
+	for _, c := range m.Columns {
+		c.Method = "Search"
+		if len(m.Columns) > 1 || taken(c.Method) {
+			c.Method += c.FieldName
+		}
+		if taken(c.Method) {
+			log.Printf("warning: no search helpers for %s.%s: %s is a field of %s", modelStructName, c.FieldName, c.Method, modelStructName)
+			continue
+		}
+		columns = append(columns, c)
+	}
+	m.Columns = columns
+	return m, len(m.Columns) > 0
+}
+
+// generatePgSearch writes a <model>.search.gen.go file into the query package for every model
+// with tsvector columns.
+func generatePgSearch(cfg ConversionConfig, models []pgSearchModel) {
+	sort.Slice(models, func(i, j int) bool { return models[i].FileName < models[j].FileName })
+	for _, m := range models {
+		writeGenFile(cfg.OutPath, m.FileName+".search.gen.go", pgSearchTemplate, struct {
+			PackageName string
+			pgSearchModel
+		}{filepath.Base(cfg.OutPath), m})
+	}
+}
+
+var pgSearchTemplate = `// Code generated by gormdb2struct; DO NOT EDIT.
+// Full-text search helpers for the tsvector columns of {{.ModelStructName}}.
+
+package {{.PackageName}}
+
+import (
+	"github.com/dan-sherwin/gormdb2struct/pgtypes"
+	"gorm.io/gen/field"
+)
+{{$r := .Receiver}}{{$q := .QueryStructName}}{{$do := printf "I%sDo" .ModelStructName}}
+{{- range .Columns}}
+// {{.Method}} matches {{.ColumnName}} against text, a web search style query such as
+// "fat rat" or cat -dog parsed by websearch_to_tsquery, best matches first. config is the text
+// search configuration, such as english; empty uses default_text_search_config.
+func ({{$r}} {{$q}}) {{.Method}}(text, config string) {{$do}} {
+	return {{$r}}.{{.Method}}Query(pgtypes.WebSearchToTSQuery(config, text))
+}
+
+// {{.Method}}TSQuery matches {{.ColumnName}} against a query built with pgtypes.TSTerm, such as
+// pgtypes.TSTerm("fat").And(pgtypes.TSTerm("ra").Prefix()), normalized by to_tsquery, best
+// matches first.
+func ({{$r}} {{$q}}) {{.Method}}TSQuery(query pgtypes.TSQuery, config string) {{$do}} {
+	return {{$r}}.{{.Method}}Query(pgtypes.ToTSQuery(config, query))
+}
+
+// {{.Method}}Query matches {{.ColumnName}} against a tsquery expression, such as
+// pgtypes.PlainToTSQuery, and orders the rows by ts_rank, best matches first.
+func ({{$r}} {{$q}}) {{.Method}}Query(query field.Expr) {{$do}} {
+	return {{$r}}.Where(pgtypes.TSMatch({{$r}}.{{.FieldName}}, query)).Order(pgtypes.TSRank({{$r}}.{{.FieldName}}, query).Desc())
+}
+{{- end}}
+`
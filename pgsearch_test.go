@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/gen"
+)
+
+func TestNewPgSearchModel(t *testing.T) {
+	for _, c := range []struct {
+		fields []gen.Field
+		want   []string
+	}{
+		{[]gen.Field{{Name: "ID", Type: "int64"}, {Name: "Body", Type: "*pgtypes.TSVector"}}, []string{"Search"}},
+		{[]gen.Field{{Name: "Body", Type: "pgtypes.TSVector"}, {Name: "TitleTsv", Type: "pgtypes.TSVector"}}, []string{"SearchBody", "SearchTitleTsv"}},
+		{[]gen.Field{{Name: "Search", Type: "pgtypes.TSVector"}}, []string{"SearchSearch"}},
+		{[]gen.Field{{Name: "Title", Type: "string"}}, nil},
+	} {
+		m, ok := newPgSearchModel("documents", "Document", "document", c.fields)
+		if ok != (c.want != nil) || len(m.Columns) != len(c.want) {
+			t.Errorf("%v: got %+v", c.want, m.Columns)
+			continue
+		}
+		for i, col := range m.Columns {
+			if col.Method != c.want[i] {
+				t.Errorf("method %s, want %s", col.Method, c.want[i])
+			}
+		}
+	}
+}
+
+func TestGeneratePgSearch(t *testing.T) {
+	outPath := filepath.Join(t.TempDir(), "query")
+	m, _ := newPgSearchModel("documents", "Document", "document", []gen.Field{{Name: "Body", ColumnName: "body", Type: "pgtypes.TSVector"}})
+	generatePgSearch(ConversionConfig{OutPath: outPath}, []pgSearchModel{m})
+	b, err := os.ReadFile(filepath.Join(outPath, "documents.search.gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustContain(t, string(b), "package query")
+	mustContain(t, string(b), "func (d document) Search(text, config string) IDocumentDo {")
+	mustContain(t, string(b), "return d.Where(pgtypes.TSMatch(d.Body, query)).Order(pgtypes.TSRank(d.Body, query).Desc())")
+}
@@ -0,0 +1,439 @@
+package pgtypes
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Array is a one-dimensional PostgreSQL array of T. Elements are converted with the ArrayCodec
+// registered for T; types without one are scanned and formatted like composite type fields
+// (see ScanRecordField), so numbers, strings, booleans, []byte and any sql.Scanner that is also
+// a driver.Valuer work out of the box. NULL elements cannot be scanned into an Array.
+type Array[T any] []T
+
+// Named arrays of the common element types.
+type (
+	StringArray   = Array[string]
+	Int32Array    = Array[int32]
+	Int64Array    = Array[int64]
+	Float64Array  = Array[float64]
+	BoolArray     = Array[bool]
+	UUIDArray     = Array[uuid.UUID]
+	TimeArray     = Array[time.Time]
+	DecimalArray  = Array[Decimal]
+	IntervalArray = Array[Interval]
+)
+
+// ArrayCodec converts array elements of type T to and from their PostgreSQL text form.
+type ArrayCodec[T any] struct {
+	TypeName  string                        // element type for GormDataType, e.g. "integer" for integer[]
+	Quote     bool                          // write every element quoted, not just those that need it
+	Delimiter byte                          // element separator, ',' when zero; box arrays use ';'
+	Cast      string                        // array type GormValue writes through, e.g. numeric[] for money[]
+	Parse     func(text string) (T, error)  // parses the text of a non-NULL element
+	Format    func(value T) (string, error) // formats an element
+}
+
+func (c ArrayCodec[T]) delimiter() byte {
+	if c.Delimiter == 0 {
+		return ','
+	}
+	return c.Delimiter
+}
+
+var arrayCodecs sync.Map // reflect.Type -> ArrayCodec[T]
+
+// RegisterArrayCodec sets the codec Array[T] uses for its elements, replacing any earlier one.
+func RegisterArrayCodec[T any](codec ArrayCodec[T]) {
+	arrayCodecs.Store(reflect.TypeFor[T](), codec)
+}
+
+func init() {
+	RegisterArrayCodec(ArrayCodec[string]{
+		TypeName: "text",
+		Quote:    true,
+		Parse:    func(text string) (string, error) { return text, nil },
+		Format:   func(value string) (string, error) { return value, nil },
+	})
+	RegisterArrayCodec(ArrayCodec[uuid.UUID]{
+		TypeName: "uuid",
+		Quote:    true,
+		Parse:    uuid.Parse,
+		Format:   func(value uuid.UUID) (string, error) { return value.String(), nil },
+	})
+	RegisterArrayCodec(ArrayCodec[time.Time]{
+		TypeName: "timestamptz",
+		Quote:    true,
+		Parse:    parseRecordTime,
+		Format:   func(value time.Time) (string, error) { return value.Format(time.RFC3339Nano), nil },
+	})
+	RegisterArrayCodec(ArrayCodec[json.RawMessage]{
+		TypeName: "jsonb",
+		Quote:    true,
+		Parse: func(text string) (json.RawMessage, error) {
+			if !json.Valid([]byte(text)) {
+				return nil, fmt.Errorf("invalid JSON array element %q", text)
+			}
+			return json.RawMessage(text), nil
+		},
+		Format: func(value json.RawMessage) (string, error) { return string(value), nil },
+	})
+	RegisterArrayCodec(ArrayCodec[Money]{
+		TypeName: "money",
+		Cast:     "numeric[]",
+		Parse:    ParseMoney,
+		Format:   func(value Money) (string, error) { return value.String(), nil },
+	})
+	RegisterArrayCodec(ArrayCodec[Box]{
+		TypeName:  "box",
+		Delimiter: ';',
+		Parse:     ParseBox,
+		Format:    func(value Box) (string, error) { return NewBox(value.High, value.Low).String(), nil },
+	})
+	RegisterArrayCodec(ArrayCodec[netip.Prefix]{
+		TypeName: "inet",
+		Parse: func(text string) (netip.Prefix, error) {
+			if strings.Contains(text, "/") {
+				return netip.ParsePrefix(text)
+			}
+			addr, err := netip.ParseAddr(text)
+			if err != nil {
+				return netip.Prefix{}, err
+			}
+			return netip.PrefixFrom(addr, addr.BitLen()), nil
+		},
+		Format: func(value netip.Prefix) (string, error) {
+			if value.Bits() == value.Addr().BitLen() {
+				return value.Addr().String(), nil
+			}
+			return value.String(), nil
+		},
+	})
+}
+
+// arrayCodecFor returns the codec registered for T or one built on ScanRecordField and
+// FormatRecordField.
+func arrayCodecFor[T any]() ArrayCodec[T] {
+	if codec, ok := arrayCodecs.Load(reflect.TypeFor[T]()); ok {
+		return codec.(ArrayCodec[T])
+	}
+	return ArrayCodec[T]{
+		TypeName: arrayElementTypeName[T](),
+		Parse: func(text string) (T, error) {
+			var value T
+			err := ScanRecordField(&value, &text)
+			return value, err
+		},
+		Format: func(value T) (string, error) {
+			text, err := FormatRecordField(value)
+			if err == nil && text == nil {
+				err = fmt.Errorf("cannot format %T as a non-NULL array element", value)
+			}
+			if err != nil {
+				return "", err
+			}
+			return *text, nil
+		},
+	}
+}
+
+// arrayElementTypeName returns the PostgreSQL type of T: its GormDataType, or the type matching
+// its kind.
+func arrayElementTypeName[T any]() string {
+	var zero T
+	if t, ok := any(zero).(interface{ GormDataType() string }); ok {
+		return t.GormDataType()
+	}
+	if _, ok := any(zero).([]byte); ok {
+		return "bytea"
+	}
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int8, reflect.Int16, reflect.Uint8:
+		return "smallint"
+	case reflect.Int32, reflect.Uint16:
+		return "integer"
+	case reflect.Int, reflect.Int64, reflect.Uint32:
+		return "bigint"
+	case reflect.Uint, reflect.Uint64:
+		return "numeric"
+	case reflect.Float32:
+		return "real"
+	case reflect.Float64:
+		return "double precision"
+	}
+	return "text"
+}
+
+func (a *Array[T]) Scan(src interface{}) error {
+	if src == nil {
+		*a = nil
+		return nil
+	}
+	codec := arrayCodecFor[T]()
+	parts, err := arrayElements(src, fmt.Sprintf("%T", *a), codec.delimiter())
+	if err != nil {
+		return err
+	}
+	out := make(Array[T], len(parts))
+	for i, p := range parts {
+		if out[i], err = codec.Parse(p); err != nil {
+			return err
+		}
+	}
+	*a = out
+	return nil
+}
+
+func (a Array[T]) Value() (driver.Value, error) {
+	if len(a) == 0 {
+		return "{}", nil
+	}
+	codec := arrayCodecFor[T]()
+	strs := make([]string, len(a))
+	for i, v := range a {
+		var err error
+		if strs[i], err = codec.Format(v); err != nil {
+			return nil, err
+		}
+	}
+	return formatArrayElements(strs, codec.Quote, codec.delimiter()), nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (a Array[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]T(a))
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (a *Array[T]) UnmarshalJSON(data []byte) error {
+	var tmp []T
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*a = Array[T](tmp)
+	return nil
+}
+
+// MarshalText writes the elements separated by commas, using their own MarshalText when they
+// have one.
+func (a Array[T]) MarshalText() ([]byte, error) {
+	strs, err := a.texts()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strings.Join(strs, ",")), nil
+}
+
+// UnmarshalText reads comma-separated elements as written by MarshalText.
+func (a *Array[T]) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*a = Array[T]{}
+		return nil
+	}
+	codec := arrayCodecFor[T]()
+	trim := reflect.TypeFor[T]().Kind() != reflect.String
+	parts := strings.Split(string(data), ",")
+	out := make(Array[T], len(parts))
+	for i, s := range parts {
+		if trim {
+			s = strings.TrimSpace(s)
+		}
+		if u, ok := any(&out[i]).(encoding.TextUnmarshaler); ok {
+			if err := u.UnmarshalText([]byte(s)); err != nil {
+				return err
+			}
+			continue
+		}
+		var err error
+		if out[i], err = codec.Parse(s); err != nil {
+			return err
+		}
+	}
+	*a = out
+	return nil
+}
+
+func (a Array[T]) texts() ([]string, error) {
+	codec := arrayCodecFor[T]()
+	strs := make([]string, len(a))
+	for i, v := range a {
+		if m, ok := any(v).(encoding.TextMarshaler); ok {
+			b, err := m.MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			strs[i] = string(b)
+			continue
+		}
+		var err error
+		if strs[i], err = codec.Format(v); err != nil {
+			return nil, err
+		}
+	}
+	return strs, nil
+}
+
+// GormDataType returns the general data type
+func (Array[T]) GormDataType() string {
+	return arrayCodecFor[T]().TypeName + "[]"
+}
+
+// GormValue writes the array as Value does. On PostgreSQL, the literal of an element type whose
+// codec has a Cast is cast through it, as money[] is through numeric[].
+func (a Array[T]) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	v, err := a.Value()
+	if err != nil {
+		_ = db.AddError(err)
+	}
+	return castArrayValue[T](db, v)
+}
+
+// castArrayValue is the expression GormValue writes for Array[T] and NullableArray[T].
+func castArrayValue[T any](db *gorm.DB, v driver.Value) clause.Expr {
+	codec := arrayCodecFor[T]()
+	if codec.Cast == "" || db.Dialector.Name() != "postgres" {
+		return clause.Expr{SQL: "?", Vars: []interface{}{v}}
+	}
+	return clause.Expr{SQL: "?::" + codec.Cast + "::" + codec.TypeName + "[]", Vars: []interface{}{v}}
+}
+
+// GormDBDataType uses the element's own GormDBDataType when it has one, so a DecimalArray keeps
+// the precision and scale of its field.
+func (a Array[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() != "postgres" {
+		return ""
+	}
+	var zero T
+	if t, ok := any(zero).(interface {
+		GormDBDataType(*gorm.DB, *schema.Field) string
+	}); ok {
+		if dbType := t.GormDBDataType(db, field); dbType != "" {
+			return dbType + "[]"
+		}
+	}
+	return a.GormDataType()
+}
+
+// FromSlice creates a new Array from a []T
+func (Array[T]) FromSlice(s []T) Array[T] {
+	return Array[T](s)
+}
+
+// AsSlice returns the Array as a []T
+func (a Array[T]) AsSlice() []T {
+	return []T(a)
+}
+
+// AsStringSlice returns the text of every element, as MarshalText writes them.
+func (a Array[T]) AsStringSlice() []string {
+	strs, _ := a.texts()
+	return strs
+}
+
+// String implements fmt.Stringer
+func (a Array[T]) String() string {
+	strs, _ := a.texts()
+	return strings.Join(strs, ",")
+}
+
+// Len implements sort.Interface
+func (a Array[T]) Len() int { return len(a) }
+
+// Swap implements sort.Interface
+func (a Array[T]) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+
+// Less orders elements through a Compare method or their basic kind, and by their text otherwise.
+func (a Array[T]) Less(i, j int) bool {
+	if c, ok := compareValues(a[i], a[j]); ok {
+		return c < 0
+	}
+	texts, _ := Array[T]{a[i], a[j]}.texts()
+	return texts[0] < texts[1]
+}
+
+// Contains returns true if the value exists in the array
+func (a Array[T]) Contains(val T) bool {
+	return a.IndexOf(val) >= 0
+}
+
+// IndexOf returns the index of the value, or -1 if not found
+func (a Array[T]) IndexOf(val T) int {
+	for i, x := range a {
+		if equalValues(x, val) {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsEmpty returns true if the array has no elements
+func (a Array[T]) IsEmpty() bool {
+	return len(a) == 0
+}
+
+// Unique returns a new Array with duplicate values removed
+func (a Array[T]) Unique() Array[T] {
+	var out Array[T]
+	for _, v := range a {
+		if !out.Contains(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Filter returns a new Array with elements matching the filter
+func (a Array[T]) Filter(f func(T) bool) Array[T] {
+	var out Array[T]
+	for _, v := range a {
+		if f(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Append returns a new Array with the specified values added
+func (a Array[T]) Append(vals ...T) Array[T] {
+	return append(a, vals...)
+}
+
+// Equals returns true if the other Array has the same values in order
+func (a Array[T]) Equals(b Array[T]) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !equalValues(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalValues compares two values through an Equal method, ==, or reflect.DeepEqual for values
+// that are not comparable, such as interfaces holding slices.
+func equalValues[T any](a, b T) bool {
+	if e, ok := any(a).(interface{ Equal(T) bool }); ok {
+		return e.Equal(b)
+	}
+	if va, vb := reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem(); va.Comparable() && vb.Comparable() {
+		return va.Equal(vb)
+	}
+	return reflect.DeepEqual(a, b)
+}
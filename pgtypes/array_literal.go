@@ -0,0 +1,385 @@
+package pgtypes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ArrayDimension is one dimension of a PostgreSQL array. LowerBound is 1 unless the literal
+// carries a dimension decoration such as [0:2]={a,b,c}.
+type ArrayDimension struct {
+	Length     int
+	LowerBound int
+}
+
+// ParseArray splits a one-dimensional PostgreSQL array literal such as {a,"b c",NULL,"d\"e"}
+// into its elements. Quoted elements are unescaped and NULL elements are returned as nil.
+// Dimension decorations are accepted and ignored; multi-dimensional arrays are rejected.
+func ParseArray(s string) ([]*string, error) {
+	elems, dims, err := ParseArrayLiteral(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(dims) > 1 {
+		return nil, fmt.Errorf("expected a one-dimensional array, got %d dimensions in %q", len(dims), s)
+	}
+	return elems, nil
+}
+
+// ParseArrayLiteral parses a PostgreSQL array literal of any dimension following the array I/O
+// rules: elements may be double-quoted, backslash escapes any character, unquoted NULL is a
+// NULL element and whitespace around elements is ignored. {{1,2},{3,NULL}} yields the elements
+// in row-major order and the dimensions outermost first; an empty array has no dimensions.
+// Sub-arrays must all have the same length, and a [lower:upper] decoration must match the data.
+func ParseArrayLiteral(s string) ([]*string, []ArrayDimension, error) {
+	return parseArrayLiteral(s, ',')
+}
+
+// parseArrayLiteral is ParseArrayLiteral for elements separated by delim, which is ',' for
+// every built-in type but box, whose arrays use ';'.
+func parseArrayLiteral(s string, delim byte) ([]*string, []ArrayDimension, error) {
+	p := &arrayParser{s: s, delim: delim, leafDepth: -1}
+	p.skipSpace()
+	var bounds []ArrayDimension
+	if p.peek() == '[' {
+		var err error
+		if bounds, err = p.parseDecoration(); err != nil {
+			return nil, nil, fmt.Errorf("invalid array literal %q: %w", s, err)
+		}
+	}
+	if p.peek() != '{' {
+		return nil, nil, fmt.Errorf("invalid array literal %q: expected '{'", s)
+	}
+	p.i++
+	if err := p.parseLevel(0); err != nil {
+		return nil, nil, fmt.Errorf("invalid array literal %q: %w", s, err)
+	}
+	p.skipSpace()
+	if p.i != len(s) {
+		return nil, nil, fmt.Errorf("invalid array literal %q: junk after closing brace", s)
+	}
+	dims := make([]ArrayDimension, len(p.lengths))
+	for i, n := range p.lengths {
+		dims[i] = ArrayDimension{Length: n, LowerBound: 1}
+	}
+	if bounds != nil {
+		if len(bounds) != len(dims) {
+			return nil, nil, fmt.Errorf("invalid array literal %q: decoration has %d dimensions, data has %d", s, len(bounds), len(dims))
+		}
+		for i := range bounds {
+			if bounds[i].Length != dims[i].Length {
+				return nil, nil, fmt.Errorf("invalid array literal %q: decoration does not match the array's dimensions", s)
+			}
+		}
+		dims = bounds
+	}
+	return p.elems, dims, nil
+}
+
+type arrayParser struct {
+	s         string
+	delim     byte
+	i         int
+	elems     []*string
+	lengths   []int // length of each dimension, -1 until a level of that depth has been closed
+	leafDepth int   // depth of the elements, -1 until the first element
+}
+
+func (p *arrayParser) peek() byte {
+	if p.i < len(p.s) {
+		return p.s[p.i]
+	}
+	return 0
+}
+
+func (p *arrayParser) skipSpace() {
+	for p.i < len(p.s) && isArraySpace(p.s[p.i]) {
+		p.i++
+	}
+}
+
+// parseDecoration reads dimension bounds such as [1:3][0:1]= in front of the array.
+func (p *arrayParser) parseDecoration() ([]ArrayDimension, error) {
+	var dims []ArrayDimension
+	for p.peek() == '[' {
+		end := strings.IndexByte(p.s[p.i:], ']')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated dimension")
+		}
+		lower, upper, hasLower := strings.Cut(p.s[p.i+1:p.i+end], ":")
+		if !hasLower {
+			lower, upper = "1", lower
+		}
+		lb, err1 := strconv.Atoi(strings.TrimSpace(lower))
+		ub, err2 := strconv.Atoi(strings.TrimSpace(upper))
+		if err1 != nil || err2 != nil || ub < lb-1 {
+			return nil, fmt.Errorf("invalid dimension %q", p.s[p.i:p.i+end+1])
+		}
+		dims = append(dims, ArrayDimension{Length: ub - lb + 1, LowerBound: lb})
+		p.i += end + 1
+		p.skipSpace()
+	}
+	if p.peek() != '=' {
+		return nil, fmt.Errorf("expected '=' after dimensions")
+	}
+	p.i++
+	p.skipSpace()
+	return dims, nil
+}
+
+// parseLevel parses the rest of an array level whose opening brace has been consumed.
+func (p *arrayParser) parseLevel(depth int) error {
+	p.skipSpace()
+	if p.peek() == '}' {
+		p.i++
+		if depth > 0 {
+			return fmt.Errorf("empty sub-array")
+		}
+		return nil
+	}
+	count := 0
+	for {
+		p.skipSpace()
+		if p.peek() == '{' {
+			if p.leafDepth >= 0 && depth >= p.leafDepth {
+				return fmt.Errorf("sub-arrays must have matching dimensions")
+			}
+			p.i++
+			if err := p.parseLevel(depth + 1); err != nil {
+				return err
+			}
+		} else {
+			if p.leafDepth < 0 {
+				p.leafDepth = depth
+			} else if p.leafDepth != depth {
+				return fmt.Errorf("sub-arrays must have matching dimensions")
+			}
+			elem, err := p.parseElement()
+			if err != nil {
+				return err
+			}
+			p.elems = append(p.elems, elem)
+		}
+		count++
+		p.skipSpace()
+		switch p.peek() {
+		case p.delim:
+			p.i++
+		case '}':
+			p.i++
+			for len(p.lengths) <= depth {
+				p.lengths = append(p.lengths, -1)
+			}
+			if p.lengths[depth] < 0 {
+				p.lengths[depth] = count
+			} else if p.lengths[depth] != count {
+				return fmt.Errorf("sub-arrays must have matching dimensions")
+			}
+			return nil
+		case 0:
+			return fmt.Errorf("missing closing brace")
+		default:
+			return fmt.Errorf("unexpected %q", p.peek())
+		}
+	}
+}
+
+// parseElement reads one quoted or unquoted element; unquoted NULL is returned as nil.
+func (p *arrayParser) parseElement() (*string, error) {
+	var b strings.Builder
+	if p.peek() == '"' {
+		for p.i++; p.i < len(p.s) && p.s[p.i] != '"'; p.i++ {
+			if p.s[p.i] == '\\' {
+				p.i++
+				if p.i == len(p.s) {
+					break
+				}
+			}
+			b.WriteByte(p.s[p.i])
+		}
+		if p.i == len(p.s) {
+			return nil, fmt.Errorf("unterminated quoted element")
+		}
+		p.i++
+		elem := b.String()
+		return &elem, nil
+	}
+	escaped := false
+	keep := 0 // length of b up to the last character that is not trailing whitespace
+	for ; p.i < len(p.s) && p.s[p.i] != p.delim && p.s[p.i] != '}'; p.i++ {
+		c := p.s[p.i]
+		switch c {
+		case '{', '"':
+			return nil, fmt.Errorf("unexpected %q", c)
+		case '\\':
+			p.i++
+			if p.i == len(p.s) {
+				return nil, fmt.Errorf("trailing backslash")
+			}
+			escaped = true
+			b.WriteByte(p.s[p.i])
+			keep = b.Len()
+			continue
+		}
+		b.WriteByte(c)
+		if !isArraySpace(c) {
+			keep = b.Len()
+		}
+	}
+	elem := b.String()[:keep]
+	if elem == "" && !escaped {
+		return nil, fmt.Errorf("empty element")
+	}
+	if !escaped && strings.EqualFold(elem, "NULL") {
+		return nil, nil
+	}
+	return &elem, nil
+}
+
+// FormatArray builds a one-dimensional PostgreSQL array literal. Elements are quoted when
+// needed and nil elements are written as NULL.
+func FormatArray(elems []*string) string {
+	return formatArray(elems, false, ',')
+}
+
+// formatArray is FormatArray, optionally quoting every non-NULL element, with elements
+// separated by delim.
+func formatArray(elems []*string, quoteAll bool, delim byte) string {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, e := range elems {
+		if i > 0 {
+			b.WriteByte(delim)
+		}
+		writeArrayElement(&b, e, quoteAll, delim)
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// FormatArrayLiteral builds an array literal of any dimension from elements in row-major order.
+// A dimension decoration is written when a lower bound is not 1. It is the inverse of
+// ParseArrayLiteral.
+func FormatArrayLiteral(elems []*string, dims []ArrayDimension) (string, error) {
+	return formatArrayLiteral(elems, dims, false, ',')
+}
+
+// formatArrayLiteral is FormatArrayLiteral, optionally quoting every non-NULL element, with
+// elements separated by delim.
+func formatArrayLiteral(elems []*string, dims []ArrayDimension, quoteAll bool, delim byte) (string, error) {
+	n := 0
+	if len(dims) > 0 {
+		n = 1
+	}
+	decorate := false
+	for _, d := range dims {
+		n *= d.Length
+		decorate = decorate || d.LowerBound != 1
+	}
+	if n != len(elems) || len(dims) > 0 && n == 0 {
+		return "", fmt.Errorf("array dimensions %v do not match %d elements", dims, len(elems))
+	}
+	var b strings.Builder
+	if decorate {
+		for _, d := range dims {
+			fmt.Fprintf(&b, "[%d:%d]", d.LowerBound, d.LowerBound+d.Length-1)
+		}
+		b.WriteByte('=')
+	}
+	if len(dims) == 0 {
+		b.WriteString("{}")
+		return b.String(), nil
+	}
+	writeArrayLevel(&b, elems, dims, quoteAll, delim)
+	return b.String(), nil
+}
+
+func writeArrayLevel(b *strings.Builder, elems []*string, dims []ArrayDimension, quoteAll bool, delim byte) {
+	b.WriteByte('{')
+	step := len(elems) / dims[0].Length
+	for i := 0; i < dims[0].Length; i++ {
+		if i > 0 {
+			b.WriteByte(delim)
+		}
+		if len(dims) == 1 {
+			writeArrayElement(b, elems[i], quoteAll, delim)
+		} else {
+			writeArrayLevel(b, elems[i*step:(i+1)*step], dims[1:], quoteAll, delim)
+		}
+	}
+	b.WriteByte('}')
+}
+
+func writeArrayElement(b *strings.Builder, e *string, quoteAll bool, delim byte) {
+	if e == nil {
+		b.WriteString("NULL")
+		return
+	}
+	s := *e
+	if !quoteAll && s != "" && !strings.EqualFold(s, "NULL") && !strings.ContainsAny(s, "{}\"\\ \t\n\r\v\f") && strings.IndexByte(s, delim) < 0 {
+		b.WriteString(s)
+		return
+	}
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('"')
+}
+
+func isArraySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// arrayLiteral returns the elements of the one-dimensional array literal in src, a string or
+// []byte with elements separated by delim, for scanning into the array type named target. NULL
+// elements are nil.
+func arrayLiteral(src interface{}, target string, delim byte) ([]*string, error) {
+	var s string
+	switch t := src.(type) {
+	case []byte:
+		s = string(t)
+	case string:
+		s = t
+	default:
+		return nil, fmt.Errorf("cannot scan type %T into %s", src, target)
+	}
+	elems, dims, err := parseArrayLiteral(s, delim)
+	if err != nil {
+		return nil, err
+	}
+	if len(dims) > 1 {
+		return nil, fmt.Errorf("expected a one-dimensional array, got %d dimensions in %q", len(dims), s)
+	}
+	return elems, nil
+}
+
+// arrayElements is arrayLiteral for array types that cannot represent NULL elements; it rejects
+// them.
+func arrayElements(src interface{}, target string, delim byte) ([]string, error) {
+	elems, err := arrayLiteral(src, target, delim)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, len(elems))
+	for i, e := range elems {
+		if e == nil {
+			return nil, fmt.Errorf("cannot scan NULL element into %s, use a NullableArray", target)
+		}
+		out[i] = *e
+	}
+	return out, nil
+}
+
+// formatArrayElements builds a one-dimensional array literal from element texts.
+func formatArrayElements(strs []string, quoteAll bool, delim byte) string {
+	elems := make([]*string, len(strs))
+	for i := range strs {
+		elems[i] = &strs[i]
+	}
+	return formatArray(elems, quoteAll, delim)
+}
@@ -0,0 +1,225 @@
+package pgtypes
+
+import (
+	"encoding/json"
+	"net/netip"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArray_ElementTypes(t *testing.T) {
+	var i16 Array[int16]
+	if err := i16.Scan(`{3,-1,2}`); err != nil || i16[1] != -1 {
+		t.Fatalf("int2[]: %v (%v)", i16, err)
+	}
+	if err := i16.Scan(`{40000}`); err == nil {
+		t.Fatal("expected int2 overflow error")
+	}
+	var f32 Array[float32]
+	if err := f32.Scan(`{1.5,-2}`); err != nil || f32[0] != 1.5 {
+		t.Fatalf("float4[]: %v (%v)", f32, err)
+	}
+	var bs Array[[]byte]
+	if err := bs.Scan(`{"\\x0102","\\x"}`); err != nil || len(bs) != 2 || bs[0][1] != 2 {
+		t.Fatalf("bytea[]: %v (%v)", bs, err)
+	}
+	if v, _ := bs.Value(); v != `{"\\x0102","\\x"}` {
+		t.Fatalf("bytea[] value: %v", v)
+	}
+	var js Array[json.RawMessage]
+	if err := js.Scan(`{"{\"a\": 1}",null,"[1,2]"}`); err == nil {
+		t.Fatal("expected error for NULL jsonb element")
+	}
+	if err := js.Scan(`{"{\"a\": 1}","[1,2]"}`); err != nil || string(js[1]) != "[1,2]" {
+		t.Fatalf("jsonb[]: %v (%v)", js, err)
+	}
+	if b, _ := json.Marshal(js); string(b) != `[{"a":1},[1,2]]` {
+		t.Fatalf("jsonb[] json: %s", b)
+	}
+	var ips Array[netip.Prefix]
+	if err := ips.Scan(`{10.0.0.1,192.168.0.5/24,::1}`); err != nil || ips[1].Bits() != 24 || ips[1].Addr().String() != "192.168.0.5" {
+		t.Fatalf("inet[]: %v (%v)", ips, err)
+	}
+	if v, _ := ips.Value(); v != "{10.0.0.1,192.168.0.5/24,::1}" {
+		t.Fatalf("inet[] value: %v", v)
+	}
+	var ranges Array[Range[int32]]
+	if err := ranges.Scan(`{"[1,5)",empty}`); err != nil || !ranges[0].Contains(4) || !ranges[1].IsEmpty() {
+		t.Fatalf("int4range[]: %v (%v)", ranges, err)
+	}
+	if v, _ := ranges.Value(); v != `{"[1,5)",empty}` {
+		t.Fatalf("int4range[] value: %v", v)
+	}
+	for got, want := range map[string]string{
+		Array[int16]{}.GormDataType():           "smallint[]",
+		Array[float32]{}.GormDataType():         "real[]",
+		Array[[]byte]{}.GormDataType():          "bytea[]",
+		Array[json.RawMessage]{}.GormDataType(): "jsonb[]",
+		Array[Range[int64]]{}.GormDataType():    "int8range[]",
+		StringArray{}.GormDataType():            "text[]",
+		DecimalArray{}.GormDataType():           "numeric[]",
+	} {
+		if got != want {
+			t.Errorf("GormDataType = %s, want %s", got, want)
+		}
+	}
+}
+
+func TestArray_Helpers(t *testing.T) {
+	a := Int32Array{3, 1, 3, 2}
+	sort.Sort(a)
+	if !a.Equals(Int32Array{1, 2, 3, 3}) || !a.Unique().Equals(Int32Array{1, 2, 3}) || a.IndexOf(3) != 2 {
+		t.Fatalf("unexpected helpers result: %v", a)
+	}
+	if got := a.Filter(func(v int32) bool { return v > 1 }).Append(7); !got.Equals(Int32Array{2, 3, 3, 7}) {
+		t.Fatalf("unexpected filter result: %v", got)
+	}
+	b := BoolArray{true, false}
+	sort.Sort(b)
+	if b[0] || b.String() != "false,true" {
+		t.Fatalf("unexpected bool order: %v", b)
+	}
+	d := DecimalArray{MustParseDecimal("1.0"), MustParseDecimal("1.00"), MustParseDecimal("0.5")}
+	if len(d.Unique()) != 2 || !d.Contains(MustParseDecimal("0.50")) {
+		t.Fatalf("decimal equality: %v", d.Unique())
+	}
+	ts := TimeArray{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	var back TimeArray
+	if err := back.UnmarshalText([]byte(ts.String())); err != nil || !back.Equals(ts) {
+		t.Fatalf("time text roundtrip: %v (%v)", back, err)
+	}
+	var dates DateArray
+	if err := dates.Scan(`{2024-01-02,2024-12-31}`); err != nil || len(dates) != 2 || dates[1].Month() != time.December {
+		t.Fatalf("date[] scan: %v (%v)", dates, err)
+	}
+	if v, _ := dates.Value(); v != "{2024-01-02,2024-12-31}" || dates.GormDataType() != "date[]" {
+		t.Fatalf("date[] value: %v %s", v, dates.GormDataType())
+	}
+	if s := (StringArray{"a", "b"}).AsStringSlice(); strings.Join(s, "|") != "a|b" {
+		t.Fatalf("unexpected string slice: %v", s)
+	}
+}
+
+type upperString string
+
+func TestRegisterArrayCodec(t *testing.T) {
+	RegisterArrayCodec(ArrayCodec[upperString]{
+		TypeName: "citext",
+		Parse:    func(text string) (upperString, error) { return upperString(strings.ToUpper(text)), nil },
+		Format:   func(value upperString) (string, error) { return strings.ToLower(string(value)), nil },
+	})
+	var a Array[upperString]
+	if err := a.Scan(`{a,"b c"}`); err != nil || a[1] != "B C" {
+		t.Fatalf("scan: %v (%v)", a, err)
+	}
+	if v, _ := a.Value(); v != `{a,"b c"}` || a.GormDataType() != "citext[]" {
+		t.Fatalf("value: %v, %s", v, a.GormDataType())
+	}
+}
+
+func TestNullableArray(t *testing.T) {
+	var s NullableArray[string]
+	if err := s.Scan(`{a,NULL,"NULL",""}`); err != nil || len(s) != 4 || s[1] != nil || *s[2] != "NULL" || *s[3] != "" {
+		t.Fatalf("scan: %v (%v)", s, err)
+	}
+	if v, _ := s.Value(); v != `{"a",NULL,"NULL",""}` {
+		t.Fatalf("value: %v", v)
+	}
+	if !s.HasNull() || len(s.Compact()) != 3 || s.ValuesOr("-")[1] != "-" || s.String() != "a,NULL,NULL," {
+		t.Fatalf("helpers: %v", s)
+	}
+	var n NullableArray[int64]
+	if err := n.Scan([]byte(`{1,null,3}`)); err != nil || n[1] != nil || *n[2] != 3 {
+		t.Fatalf("int8[]: %v (%v)", n, err)
+	}
+	if v, _ := n.Value(); v != "{1,NULL,3}" {
+		t.Fatalf("int8[] value: %v", v)
+	}
+	if !n.Equals(NullableArray[int64]{NewNullableArray[int64](1)[0], nil, NewNullableArray[int64](3)[0]}) {
+		t.Fatalf("equals: %v", n)
+	}
+	b, _ := json.Marshal(n)
+	var back NullableArray[int64]
+	if string(b) != "[1,null,3]" || json.Unmarshal(b, &back) != nil || !back.Equals(n) {
+		t.Fatalf("json: %s -> %v", b, back)
+	}
+	var d NullableArray[Decimal]
+	if err := d.Scan(`{NULL,1.50}`); err != nil || d[0] != nil || d[1].String() != "1.50" || d.GormDataType() != "numeric[]" {
+		t.Fatalf("numeric[]: %v (%v)", d, err)
+	}
+	var a Int64Array
+	if err := a.Scan(`{1,NULL}`); err == nil || !strings.Contains(err.Error(), "NullableArray") {
+		t.Fatalf("expected NULL element error, got %v", err)
+	}
+}
+
+func TestArray2D(t *testing.T) {
+	var m Array2D[int32]
+	if err := m.Scan(`{{1,2,3},{4,5,6}}`); err != nil || len(m) != 2 || m[1][2] != 6 {
+		t.Fatalf("scan: %v (%v)", m, err)
+	}
+	if v, err := m.Value(); err != nil || v != "{{1,2,3},{4,5,6}}" {
+		t.Fatalf("value: %v (%v)", v, err)
+	}
+	m[0] = append(m[0], 9) // capped rows: must not overwrite m[1]
+	if m[1][0] != 4 {
+		t.Fatalf("rows share storage: %v", m)
+	}
+	if _, err := m.Value(); err == nil || !strings.Contains(err.Error(), "not rectangular") {
+		t.Fatalf("expected rectangular error, got %v", err)
+	}
+	for _, bad := range []string{`{{1,2},{3}}`, `{1,2}`, `{{{1}}}`, `{{1,NULL}}`} {
+		if err := m.Scan(bad); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+	if err := m.Scan(`{}`); err != nil || m == nil || len(m) != 0 {
+		t.Fatalf("empty: %v (%v)", m, err)
+	}
+	var pairs Array2D[string]
+	if err := pairs.Scan(`[0:1][1:2]={{a,"b c"},{"d,e",f}}`); err != nil || pairs[1][0] != "d,e" {
+		t.Fatalf("text[][]: %v (%v)", pairs, err)
+	}
+	if v, _ := pairs.Value(); v != `{{"a","b c"},{"d,e","f"}}` || pairs.GormDataType() != "text[][]" {
+		t.Fatalf("text[][] value: %v", v)
+	}
+}
+
+func TestArray3DAndND(t *testing.T) {
+	var c Array3D[int64]
+	if err := c.Scan(`{{{1,2},{3,4}},{{5,6},{7,8}}}`); err != nil || c[1][0][1] != 6 {
+		t.Fatalf("scan: %v (%v)", c, err)
+	}
+	if v, _ := c.Value(); v != `{{{1,2},{3,4}},{{5,6},{7,8}}}` {
+		t.Fatalf("value: %v", v)
+	}
+	c[1] = c[1][:1]
+	if _, err := c.Value(); err == nil {
+		t.Fatal("expected rectangular error")
+	}
+	var n ArrayND[int32]
+	if err := n.Scan(`[0:1][1:1][1:2]={{{1,2}},{{3,4}}}`); err != nil || len(n.Dims) != 3 || n.At(1, 0, 1) != 4 {
+		t.Fatalf("scan: %+v (%v)", n, err)
+	}
+	if v, _ := n.Value(); v != `[0:1][1:1][1:2]={{{1,2}},{{3,4}}}` {
+		t.Fatalf("value: %v", v)
+	}
+	b, err := json.Marshal(n)
+	if err != nil || string(b) != `[[[1,2]],[[3,4]]]` {
+		t.Fatalf("json: %s (%v)", b, err)
+	}
+	var back ArrayND[int32]
+	if err := json.Unmarshal(b, &back); err != nil || back.At(1, 0, 0) != 3 || len(back.Dims) != 3 {
+		t.Fatalf("json roundtrip: %+v (%v)", back, err)
+	}
+	for _, bad := range []string{`[[1],[2,3]]`, `[[1],2]`, `[1,[2]]`} {
+		if err := json.Unmarshal([]byte(bad), &back); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+	if _, err := NewArrayND([]int{2, 2}, []int32{1, 2, 3}); err == nil {
+		t.Fatal("expected dimension mismatch error")
+	}
+}
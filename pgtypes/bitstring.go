@@ -0,0 +1,212 @@
+package pgtypes
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// BitString is a PostgreSQL bit(n) or bit varying(n) value: Len bits packed into Bytes, most
+// significant bit first, with the unused low bits of the last byte zero. Bit 0 is the leftmost
+// bit, as in the text form 10110. A BitString with nil Bytes is stored as NULL.
+type BitString struct {
+	Bytes []byte
+	Len   int
+}
+
+// BitStringArray is a PostgreSQL bit[] or bit varying[].
+type BitStringArray = Array[BitString]
+
+// NewBitString returns a BitString of n zero bits.
+func NewBitString(n int) BitString {
+	return BitString{Bytes: make([]byte, (n+7)/8), Len: n}
+}
+
+// BitStringFromUint64 returns the n low bits of v, as PostgreSQL's v::bit(n).
+func BitStringFromUint64(v uint64, n int) BitString {
+	b := NewBitString(n)
+	for i := 0; i < n && i < 64; i++ {
+		b.SetBit(n-1-i, v&(1<<i) != 0)
+	}
+	return b
+}
+
+// ParseBitString parses the text form, a string of 0s and 1s. The empty string is the empty
+// bit string.
+func ParseBitString(s string) (BitString, error) {
+	b := NewBitString(len(s))
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '0':
+		case '1':
+			b.SetBit(i, true)
+		default:
+			return BitString{}, fmt.Errorf("invalid bit string %q: %q is not a valid binary digit", s, s[i])
+		}
+	}
+	return b, nil
+}
+
+// Bit reports whether bit i, counted from the left, is set.
+func (b BitString) Bit(i int) bool {
+	b.check(i)
+	return b.Bytes[i/8]&(0x80>>(i%8)) != 0
+}
+
+// SetBit sets or clears bit i, counted from the left, in place.
+func (b *BitString) SetBit(i int, v bool) {
+	b.check(i)
+	if v {
+		b.Bytes[i/8] |= 0x80 >> (i % 8)
+	} else {
+		b.Bytes[i/8] &^= 0x80 >> (i % 8)
+	}
+}
+
+func (b BitString) check(i int) {
+	if i < 0 || i >= b.Len {
+		panic(fmt.Sprintf("pgtypes: bit %d out of range for a bit string of length %d", i, b.Len))
+	}
+}
+
+// Count returns the number of set bits, as PostgreSQL's bit_count.
+func (b BitString) Count() int {
+	n := 0
+	for _, c := range b.Bytes {
+		n += bits.OnesCount8(c)
+	}
+	return n
+}
+
+// Uint64 returns the bits as an unsigned number, as PostgreSQL's b::bigint. A longer string
+// keeps its 64 rightmost bits, where PostgreSQL reports an error.
+func (b BitString) Uint64() uint64 {
+	var v uint64
+	for i := 0; i < b.Len; i++ {
+		v <<= 1
+		if b.Bit(i) {
+			v |= 1
+		}
+	}
+	return v
+}
+
+// And returns the bitwise AND of b and other, as PostgreSQL's &. Both must have the same length.
+func (b BitString) And(other BitString) (BitString, error) {
+	return b.combine(other, "AND", func(x, y byte) byte { return x & y })
+}
+
+// Or returns the bitwise OR of b and other, as PostgreSQL's |. Both must have the same length.
+func (b BitString) Or(other BitString) (BitString, error) {
+	return b.combine(other, "OR", func(x, y byte) byte { return x | y })
+}
+
+// Xor returns the bitwise XOR of b and other, as PostgreSQL's #. Both must have the same length.
+func (b BitString) Xor(other BitString) (BitString, error) {
+	return b.combine(other, "XOR", func(x, y byte) byte { return x ^ y })
+}
+
+func (b BitString) combine(other BitString, op string, f func(x, y byte) byte) (BitString, error) {
+	if b.Len != other.Len {
+		return BitString{}, fmt.Errorf("cannot %s bit strings of different sizes (%d and %d)", op, b.Len, other.Len)
+	}
+	out := NewBitString(b.Len)
+	for i := range out.Bytes {
+		out.Bytes[i] = f(b.Bytes[i], other.Bytes[i])
+	}
+	return out, nil
+}
+
+// Not returns b with every bit flipped, as PostgreSQL's ~.
+func (b BitString) Not() BitString {
+	out := NewBitString(b.Len)
+	for i := range out.Bytes {
+		out.Bytes[i] = ^b.Bytes[i]
+	}
+	out.clearPadding()
+	return out
+}
+
+// ShiftLeft returns b shifted left by n bits, keeping its length, as PostgreSQL's <<.
+func (b BitString) ShiftLeft(n int) BitString {
+	out := NewBitString(b.Len)
+	for i := 0; i < b.Len; i++ {
+		if j := i + n; j >= 0 && j < b.Len && b.Bit(j) {
+			out.SetBit(i, true)
+		}
+	}
+	return out
+}
+
+// ShiftRight returns b shifted right by n bits, keeping its length, as PostgreSQL's >>.
+func (b BitString) ShiftRight(n int) BitString {
+	return b.ShiftLeft(-n)
+}
+
+func (b *BitString) clearPadding() {
+	if r := b.Len % 8; r != 0 {
+		b.Bytes[len(b.Bytes)-1] &= 0xff << (8 - r)
+	}
+}
+
+// String returns the text form, such as 10110.
+func (b BitString) String() string {
+	var s strings.Builder
+	s.Grow(b.Len)
+	for i := 0; i < b.Len; i++ {
+		if b.Bit(i) {
+			s.WriteByte('1')
+		} else {
+			s.WriteByte('0')
+		}
+	}
+	return s.String()
+}
+
+func (b *BitString) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*b = BitString{}
+	case []byte:
+		*b, err = ParseBitString(string(v))
+	case string:
+		*b, err = ParseBitString(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into BitString", src)
+	}
+	return err
+}
+
+func (b BitString) Value() (driver.Value, error) {
+	if b.Bytes == nil {
+		return nil, nil
+	}
+	if len(b.Bytes) != (b.Len+7)/8 {
+		return nil, fmt.Errorf("invalid BitString: %d bytes for %d bits", len(b.Bytes), b.Len)
+	}
+	return b.String(), nil
+}
+
+func (b BitString) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+func (b *BitString) UnmarshalText(data []byte) error {
+	return b.Scan(string(data))
+}
+
+func (BitString) GormDataType() string {
+	return "varbit"
+}
+
+func (BitString) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "varbit"
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+package pgtypes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBitString(t *testing.T) {
+	var b BitString
+	if err := b.Scan([]byte("1011000011")); err != nil || b.Len != 10 || !b.Bit(0) || b.Bit(1) || b.Count() != 5 {
+		t.Fatalf("scan: %+v (%v)", b, err)
+	}
+	if v, _ := b.Value(); v != "1011000011" || b.Uint64() != 0b1011000011 {
+		t.Fatalf("value: %v", v)
+	}
+	other := BitStringFromUint64(0b1111100000, 10)
+	for got, want := range map[string]string{
+		mustBits(b.And(other)).String(): "1011000000",
+		mustBits(b.Or(other)).String():  "1111100011",
+		mustBits(b.Xor(other)).String(): "0100100011",
+		b.Not().String():                "0100111100",
+		b.ShiftLeft(3).String():         "1000011000",
+		b.ShiftRight(3).String():        "0001011000",
+	} {
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	}
+	if b.Not().Count() != 5 {
+		t.Error("Not must leave the padding bits clear")
+	}
+	if _, err := b.And(NewBitString(3)); err == nil {
+		t.Error("expected a length mismatch error")
+	}
+	b.SetBit(1, true)
+	if b.String() != "1111000011" {
+		t.Errorf("SetBit: %s", b)
+	}
+	if _, err := ParseBitString("10a"); err == nil {
+		t.Error("expected an invalid digit error")
+	}
+	if err := b.Scan(nil); err != nil || b.Bytes != nil {
+		t.Fatalf("NULL: %+v", b)
+	}
+	if v, _ := b.Value(); v != nil {
+		t.Fatalf("NULL value: %v", v)
+	}
+	if empty, _ := ParseBitString(""); empty.Bytes == nil {
+		t.Error("the empty bit string is not NULL")
+	}
+	var a BitStringArray
+	if err := a.Scan("{101,0011}"); err != nil || a[1].Len != 4 {
+		t.Fatalf("bit[]: %v (%v)", a, err)
+	}
+	js, _ := json.Marshal(a)
+	if string(js) != `["101","0011"]` {
+		t.Fatalf("json: %s", js)
+	}
+}
+
+func mustBits(b BitString, err error) BitString {
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
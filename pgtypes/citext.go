@@ -0,0 +1,65 @@
+package pgtypes
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// CIText is a value of the citext extension type: a string that compares case-insensitively.
+// Use Equal and Compare rather than == to match PostgreSQL's comparisons.
+type CIText string
+
+// CITextArray is a PostgreSQL citext[]. Contains, IndexOf and Unique ignore case.
+type CITextArray = Array[CIText]
+
+// Equal reports whether both strings are equal ignoring case.
+func (c CIText) Equal(other CIText) bool {
+	return c.Lower() == other.Lower()
+}
+
+// Compare orders the strings by their lower-case form, as citext does.
+func (c CIText) Compare(other CIText) int {
+	return strings.Compare(c.Lower(), other.Lower())
+}
+
+// Lower returns the lower-case form citext compares.
+func (c CIText) Lower() string {
+	return strings.ToLower(string(c))
+}
+
+func (c CIText) String() string {
+	return string(c)
+}
+
+func (c *CIText) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*c = ""
+	case []byte:
+		*c = CIText(v)
+	case string:
+		*c = CIText(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into CIText", src)
+	}
+	return nil
+}
+
+func (c CIText) Value() (driver.Value, error) {
+	return string(c), nil
+}
+
+func (CIText) GormDataType() string {
+	return "citext"
+}
+
+func (CIText) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "citext"
+	}
+	return ""
+}
@@ -0,0 +1,46 @@
+package pgtypes
+
+import (
+	"database/sql/driver"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// DateArray is a PostgreSQL date[] column. It reads like TimeArray, but writes only the date of
+// each element and declares date[], so migrations keep the column type.
+type DateArray []time.Time
+
+func (a *DateArray) Scan(src interface{}) error {
+	return (*Array[time.Time])(a).Scan(src)
+}
+
+func (a DateArray) Value() (driver.Value, error) {
+	if len(a) == 0 {
+		return "{}", nil
+	}
+	strs := make([]string, len(a))
+	for i, v := range a {
+		strs[i] = v.Format(time.DateOnly)
+	}
+	return formatArrayElements(strs, false, ','), nil
+}
+
+// GormDataType returns the general data type
+func (DateArray) GormDataType() string {
+	return "date[]"
+}
+
+// GormDBDataType returns the database data type for a specific dialect
+func (DateArray) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "date[]"
+	}
+	return ""
+}
+
+// AsSlice returns the DateArray as a []time.Time
+func (a DateArray) AsSlice() []time.Time {
+	return []time.Time(a)
+}
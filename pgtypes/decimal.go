@@ -0,0 +1,290 @@
+package pgtypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Decimal is an exact decimal number for numeric and decimal columns: an arbitrary precision
+// integer scaled by a power of ten. The number of digits after the decimal point is kept, so
+// 12.50 scans, prints and marshals as 12.50. The zero value is 0.
+//
+// PostgreSQL's NaN and Infinity values are not supported.
+type Decimal struct {
+	unscaled *big.Int // never modified once set; nil means 0
+	scale    int32
+}
+
+// NewDecimal returns unscaled * 10^-scale, e.g. NewDecimal(1250, 2) is 12.50.
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{unscaled: new(big.Int).Mul(big.NewInt(unscaled), pow10(-scale))}
+	}
+	return Decimal{unscaled: big.NewInt(unscaled), scale: scale}
+}
+
+// DecimalFromFloat returns the shortest decimal that converts back to f.
+func DecimalFromFloat(f float64) (Decimal, error) {
+	return ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// ParseDecimal parses a decimal such as -12.50, .5 or 1.2e3.
+func ParseDecimal(s string) (Decimal, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+	exp := int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", orig)
+		}
+		s, exp = s[:i], e
+	}
+	sign := ""
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		sign, s = s[:1], s[1:]
+	}
+	intPart, frac, _ := strings.Cut(s, ".")
+	digits := intPart + frac
+	if digits == "" || strings.TrimLeft(digits, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", orig)
+	}
+	unscaled, _ := new(big.Int).SetString(sign+digits, 10)
+	scale := int64(len(frac)) - exp
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(int32(-scale)))
+		scale = 0
+	}
+	if scale > 1<<31-1 {
+		return Decimal{}, fmt.Errorf("invalid decimal %q: exponent out of range", orig)
+	}
+	return Decimal{unscaled: unscaled, scale: int32(scale)}, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics if s is not a valid decimal.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// rescaled returns the unscaled value of d at a scale of at least d.scale.
+func (d Decimal) rescaled(scale int32) *big.Int {
+	if scale <= d.scale {
+		return d.int()
+	}
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int32 { return d.scale }
+
+// Sign returns -1, 0 or +1.
+func (d Decimal) Sign() int { return d.int().Sign() }
+
+func (d Decimal) IsZero() bool { return d.Sign() == 0 }
+
+// Cmp compares d and other numerically: 12.5 and 12.50 are equal.
+func (d Decimal) Cmp(other Decimal) int {
+	scale := max(d.scale, other.scale)
+	return d.rescaled(scale).Cmp(other.rescaled(scale))
+}
+
+// Compare is Cmp; it lets Decimal be used as a Range element.
+func (d Decimal) Compare(other Decimal) int { return d.Cmp(other) }
+
+// Equal reports whether d and other are numerically equal.
+func (d Decimal) Equal(other Decimal) bool { return d.Cmp(other) == 0 }
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	return Decimal{unscaled: new(big.Int).Abs(d.int()), scale: d.scale}
+}
+
+// Add returns d + other at the larger of the two scales.
+func (d Decimal) Add(other Decimal) Decimal {
+	scale := max(d.scale, other.scale)
+	return Decimal{unscaled: new(big.Int).Add(d.rescaled(scale), other.rescaled(scale)), scale: scale}
+}
+
+// Sub returns d - other at the larger of the two scales.
+func (d Decimal) Sub(other Decimal) Decimal {
+	return d.Add(other.Neg())
+}
+
+// Mul returns d * other at the sum of the two scales.
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.int(), other.int()), scale: d.scale + other.scale}
+}
+
+// Div returns d / other rounded half away from zero to scale digits after the decimal point.
+// It panics if other is zero.
+func (d Decimal) Div(other Decimal, scale int32) Decimal {
+	if other.IsZero() {
+		panic("pgtypes: decimal division by zero")
+	}
+	// d/other = (d.unscaled * 10^(scale+1+other.scale-d.scale)) / other.unscaled, at scale+1.
+	num := new(big.Int).Set(d.int())
+	if shift := scale + 1 + other.scale - d.scale; shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		num.Quo(num, pow10(-shift))
+	}
+	q := num.Quo(num, other.int())
+	return Decimal{unscaled: q, scale: scale + 1}.Round(scale)
+}
+
+// Round rounds d half away from zero, as PostgreSQL's round does, to places digits after the
+// decimal point. It never increases the scale.
+func (d Decimal) Round(places int32) Decimal {
+	if places >= d.scale {
+		return d
+	}
+	p := pow10(d.scale - places)
+	q, r := new(big.Int).QuoRem(d.int(), p, new(big.Int))
+	if r.Abs(r).Lsh(r, 1).Cmp(p) >= 0 {
+		q.Add(q, big.NewInt(int64(d.Sign())))
+	}
+	return Decimal{unscaled: q, scale: places}
+}
+
+// Truncate drops the digits after places digits after the decimal point, like trunc.
+func (d Decimal) Truncate(places int32) Decimal {
+	if places >= d.scale {
+		return d
+	}
+	return Decimal{unscaled: new(big.Int).Quo(d.int(), pow10(d.scale-places)), scale: places}
+}
+
+// Float64 returns the nearest float64 to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String returns d with Scale digits after the decimal point, e.g. -12.50.
+func (d Decimal) String() string {
+	s := d.int().String()
+	if d.scale == 0 {
+		return s
+	}
+	sign := ""
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+	if n := int(d.scale) + 1 - len(s); n > 0 {
+		s = strings.Repeat("0", n) + s
+	}
+	return sign + s[:len(s)-int(d.scale)] + "." + s[len(s)-int(d.scale):]
+}
+
+func (d *Decimal) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*d = Decimal{}
+	case []byte:
+		*d, err = ParseDecimal(string(v))
+	case string:
+		*d, err = ParseDecimal(v)
+	case int64:
+		*d = NewDecimal(v, 0)
+	case float64:
+		*d, err = DecimalFromFloat(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into Decimal", src)
+	}
+	return err
+}
+
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// MarshalJSON writes d as a JSON string so that no digits are lost to float64 decoders.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts a JSON number or string.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	s := string(data)
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(data []byte) error {
+	parsed, err := ParseDecimal(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// SumDecimals returns the exact sum of values.
+func SumDecimals(values ...Decimal) Decimal {
+	var sum Decimal
+	for _, v := range values {
+		sum = sum.Add(v)
+	}
+	return sum
+}
+
+func (Decimal) GormDataType() string {
+	return "numeric"
+}
+
+func (Decimal) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return numericType(field, "")
+	}
+	return ""
+}
+
+// numericType returns numeric(precision,scale) when the field declares a precision.
+func numericType(field *schema.Field, suffix string) string {
+	if field != nil && field.Precision > 0 {
+		return fmt.Sprintf("numeric(%d,%d)%s", field.Precision, field.Scale, suffix)
+	}
+	return "numeric" + suffix
+}
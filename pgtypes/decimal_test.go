@@ -0,0 +1,114 @@
+package pgtypes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	for _, c := range []struct{ in, want string }{
+		{"12.50", "12.50"},
+		{"-0.05", "-0.05"},
+		{".5", "0.5"},
+		{"+7", "7"},
+		{"1.2e3", "1200"},
+		{"1.25E-3", "0.00125"},
+		{"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789"},
+	} {
+		d, err := ParseDecimal(c.in)
+		if err != nil || d.String() != c.want {
+			t.Fatalf("ParseDecimal(%q) = %s (%v), want %s", c.in, d, err, c.want)
+		}
+	}
+	for _, bad := range []string{"", "-", "1.2.3", "abc", "NaN", "1e"} {
+		if _, err := ParseDecimal(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+	if s := (Decimal{}).String(); s != "0" {
+		t.Fatalf("zero value prints %q", s)
+	}
+	if s := NewDecimal(-5, 3).String(); s != "-0.005" {
+		t.Fatalf("NewDecimal(-5, 3) = %s", s)
+	}
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	a, b := MustParseDecimal("0.1"), MustParseDecimal("0.20")
+	for _, c := range []struct {
+		got  Decimal
+		want string
+	}{
+		{a.Add(b), "0.30"},
+		{a.Sub(b), "-0.10"},
+		{a.Mul(b), "0.020"},
+		{MustParseDecimal("10").Div(MustParseDecimal("3"), 4), "3.3333"},
+		{MustParseDecimal("2").Div(MustParseDecimal("3"), 2), "0.67"},
+		{MustParseDecimal("-1").Div(MustParseDecimal("8"), 2), "-0.13"},
+		{MustParseDecimal("2.345").Round(2), "2.35"},
+		{MustParseDecimal("-2.345").Round(2), "-2.35"},
+		{MustParseDecimal("2.344").Round(2), "2.34"},
+		{MustParseDecimal("2.349").Truncate(2), "2.34"},
+		{MustParseDecimal("-2.5").Abs(), "2.5"},
+	} {
+		if c.got.String() != c.want {
+			t.Fatalf("got %s, want %s", c.got, c.want)
+		}
+	}
+	if a.Add(a).Add(a).Cmp(MustParseDecimal("0.3")) != 0 || !MustParseDecimal("12.5").Equal(MustParseDecimal("12.500")) {
+		t.Fatal("decimal sums should be exact")
+	}
+	if MustParseDecimal("-1").Cmp(MustParseDecimal("0.5")) >= 0 {
+		t.Fatal("unexpected ordering")
+	}
+}
+
+func TestDecimal_ScanValueJSON(t *testing.T) {
+	var d Decimal
+	for _, src := range []interface{}{[]byte("12.50"), "12.50"} {
+		if err := d.Scan(src); err != nil || d.String() != "12.50" {
+			t.Fatalf("scan %T: %s (%v)", src, d, err)
+		}
+	}
+	if err := d.Scan(int64(42)); err != nil || d.String() != "42" {
+		t.Fatalf("scan int64: %s (%v)", d, err)
+	}
+	if err := d.Scan(1.5); err != nil || d.String() != "1.5" {
+		t.Fatalf("scan float64: %s (%v)", d, err)
+	}
+	if v, err := MustParseDecimal("0.10").Value(); err != nil || v != "0.10" {
+		t.Fatalf("unexpected value: %v (%v)", v, err)
+	}
+	b, err := json.Marshal(struct{ Amount Decimal }{MustParseDecimal("1234567890.10")})
+	if err != nil || string(b) != `{"Amount":"1234567890.10"}` {
+		t.Fatalf("unexpected json: %s (%v)", b, err)
+	}
+	var out struct{ A, B Decimal }
+	if err := json.Unmarshal([]byte(`{"A":"0.10","B":12.345}`), &out); err != nil || out.A.String() != "0.10" || out.B.String() != "12.345" {
+		t.Fatalf("unexpected unmarshal: %+v (%v)", out, err)
+	}
+}
+
+func TestDecimalArray(t *testing.T) {
+	var a DecimalArray
+	if err := a.Scan(`{1.10,"2.20",-3}`); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if SumDecimals(a...).String() != "0.30" || !a.Contains(MustParseDecimal("2.2")) {
+		t.Fatalf("unexpected array: %v", a)
+	}
+	if v, err := a.Value(); err != nil || v != "{1.10,2.20,-3}" {
+		t.Fatalf("unexpected value: %v (%v)", v, err)
+	}
+	if err := a.Scan("{1,NULL}"); err == nil {
+		t.Fatal("expected error for NULL element")
+	}
+	b, _ := json.Marshal(DecimalArray{MustParseDecimal("1.50")})
+	if string(b) != `["1.50"]` {
+		t.Fatalf("unexpected json: %s", b)
+	}
+	var r NumRange
+	if err := r.Scan("[1.5,2.25)"); err != nil || !r.Contains(MustParseDecimal("2.2")) || r.Contains(MustParseDecimal("2.25")) {
+		t.Fatalf("unexpected numrange: %v (%v)", r, err)
+	}
+}
@@ -3,7 +3,6 @@ package pgtypes
 import (
 	"database/sql/driver"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
@@ -11,6 +10,10 @@ import (
 	"time"
 )
 
+// Duration is a PostgreSQL interval held as a time.Duration, the default mapping for interval
+// columns. Months and days are converted to hours, counting a month as 30 days, so '1 mon' is
+// written back as 720 hours and month arithmetic no longer follows the calendar. Map interval
+// to Interval in TypeMap to keep months and days apart.
 type Duration struct {
 	time.Duration
 }
@@ -40,8 +43,8 @@ func (d *Duration) Scan(src interface{}) error {
 }
 
 func (d Duration) Value() (driver.Value, error) {
-	// Stored in Go duration string format, e.g., '1h2m3s'
-	return d.String(), nil
+	// Written as an ISO 8601 interval (PT1H2M3S), which PostgreSQL accepts under every IntervalStyle.
+	return IntervalFromDuration(d.Duration).Format(IntervalStyleISO8601), nil
 }
 
 func (d Duration) MarshalJSON() ([]byte, error) {
@@ -101,41 +104,20 @@ func (d Duration) Equals(other Duration) bool {
 	return d.Duration == other.Duration
 }
 
-// parsePostgresInterval attempts to convert a PostgreSQL interval string into time.Duration.
-// It only supports the time portion (ignores months/years).
+// parsePostgresInterval converts a PostgreSQL interval string in any IntervalStyle into a
+// time.Duration, counting months as 30 days and days as 24 hours (see Interval.Duration).
+// Go duration strings such as 1h2m3s are accepted too.
 func parsePostgresInterval(s string) (time.Duration, error) {
 	s = strings.TrimSpace(s)
 	if s == "" {
 		return 0, nil
 	}
-
-	// Try to parse using Go's time.ParseDuration first
 	if dur, err := time.ParseDuration(s); err == nil {
 		return dur, nil
 	}
-
-	// Try parsing Postgres-style HH:MM:SS or D days HH:MM:SS
-	// This won't support months/years, which are ambiguous
-	parts := strings.Fields(s)
-	var total time.Duration
-	var offset int
-
-	if len(parts) == 3 && parts[1] == "days" {
-		days, err := time.ParseDuration(parts[0] + "24h")
-		if err != nil {
-			return 0, err
-		}
-		total += days
-		offset = 2
-	}
-
-	t, err := time.Parse("15:04:05", parts[offset])
+	iv, err := ParseInterval(s)
 	if err != nil {
-		return 0, errors.New("unsupported interval format: " + s)
+		return 0, err
 	}
-
-	total += time.Duration(t.Hour()) * time.Hour
-	total += time.Duration(t.Minute()) * time.Minute
-	total += time.Duration(t.Second()) * time.Second
-	return total, nil
+	return iv.Duration(), nil
 }
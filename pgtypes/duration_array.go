@@ -3,7 +3,6 @@ package pgtypes
 import (
 	"database/sql/driver"
 	"encoding/json"
-	"fmt"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 	"strings"
@@ -12,47 +11,13 @@ import (
 
 type DurationArray []Duration
 
+// Scan and Value go through Array[Duration]; the other helpers take time.Duration values.
 func (a *DurationArray) Scan(src interface{}) error {
-	if src == nil {
-		*a = nil
-		return nil
-	}
-	var input string
-	switch t := src.(type) {
-	case []byte:
-		input = string(t)
-	case string:
-		input = t
-	default:
-		return fmt.Errorf("cannot scan type %T into DurationArray", src)
-	}
-	input = strings.Trim(input, "{}")
-	if input == "" {
-		*a = DurationArray{}
-		return nil
-	}
-	parts := strings.Split(input, ",")
-	result := make(DurationArray, len(parts))
-	for i, p := range parts {
-		dur, err := parsePostgresInterval(strings.Trim(p, `"`))
-		if err != nil {
-			return err
-		}
-		result[i] = Duration{dur}
-	}
-	*a = result
-	return nil
+	return (*Array[Duration])(a).Scan(src)
 }
 
 func (a DurationArray) Value() (driver.Value, error) {
-	if len(a) == 0 {
-		return "{}", nil
-	}
-	strs := make([]string, len(a))
-	for i, v := range a {
-		strs[i] = fmt.Sprintf("\"%s\"", v.String())
-	}
-	return fmt.Sprintf("{%s}", strings.Join(strs, ",")), nil
+	return Array[Duration](a).Value()
 }
 
 func (a DurationArray) MarshalJSON() ([]byte, error) {
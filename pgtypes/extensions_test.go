@@ -0,0 +1,85 @@
+package pgtypes
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+)
+
+func TestHstore(t *testing.T) {
+	var h Hstore
+	if err := h.Scan([]byte(`"a"=>"1", b => NULL, "c d"=>"x\"y", e=>"NULL", f=>a=>b`)); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if v, ok := h.Get("a"); !ok || v != "1" {
+		t.Fatalf("a: %q %v", v, ok)
+	}
+	if _, ok := h.Get("b"); ok || !h.Has("b") || *h["c d"] != `x"y` || *h["e"] != "NULL" || *h["f"] != "a=>b" {
+		t.Fatalf("values: %v", h)
+	}
+	if v, _ := h.Value(); v != `"a"=>"1", "b"=>NULL, "c d"=>"x\"y", "e"=>"NULL", "f"=>"a=>b"` {
+		t.Fatalf("value: %v", v)
+	}
+	b, _ := json.Marshal(NewHstore(map[string]string{"k": "v"}))
+	if string(b) != `{"k":"v"}` {
+		t.Fatalf("json: %s", b)
+	}
+	for _, bad := range []string{`a`, `a=>`, `"a=>b`, `a=>b c=>d`} {
+		if _, err := ParseHstore(bad); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+	if err := h.Scan(""); err != nil || h == nil || len(h) != 0 {
+		t.Fatalf("empty: %v (%v)", h, err)
+	}
+	var a HstoreArray
+	if err := a.Scan(`{"\"a\"=>\"1\"",""}`); err != nil || len(a) != 2 || *a[0]["a"] != "1" || len(a[1]) != 0 {
+		t.Fatalf("hstore[]: %v (%v)", a, err)
+	}
+	if v, _ := a.Value(); v != `{"\"a\"=>\"1\"",""}` || a.GormDataType() != "hstore[]" {
+		t.Fatalf("hstore[] value: %v", v)
+	}
+}
+
+func TestLTree(t *testing.T) {
+	l, err := ParseLTree("Top.Science.Astronomy")
+	if err != nil || l.Level() != 3 || l.Parent() != "Top.Science" || l.Labels()[2] != "Astronomy" {
+		t.Fatalf("parse: %v (%v)", l, err)
+	}
+	if !LTree("Top.Science").IsAncestorOf(l) || !l.IsDescendantOf("Top") || !l.IsAncestorOf(l) || !LTree("").IsAncestorOf(l) {
+		t.Fatalf("ancestry: %v", l)
+	}
+	if LTree("Top.Sci").IsAncestorOf(l) || l.IsAncestorOf("Top") {
+		t.Fatalf("false ancestry: %v", l)
+	}
+	if l.Child("Stars") != "Top.Science.Astronomy.Stars" || LTree("").Child("Top") != "Top" || LTree("Top").Parent() != "" {
+		t.Fatal("unexpected child or parent")
+	}
+	for _, bad := range []string{"a..b", "a.", "a b", "a.b!"} {
+		if _, err := ParseLTree(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+	var a LTreeArray
+	if err := a.Scan(`{Top.Science,Top.Hobbies-and-crafts}`); err != nil || a[1].Level() != 2 {
+		t.Fatalf("ltree[]: %v (%v)", a, err)
+	}
+}
+
+func TestCIText(t *testing.T) {
+	var c CIText
+	if err := c.Scan([]byte("Alice@Example.com")); err != nil || !c.Equal("alice@example.COM") || c.String() != "Alice@Example.com" {
+		t.Fatalf("scan: %v (%v)", c, err)
+	}
+	a := CITextArray{"b", "A", "a", "B"}
+	if !a.Contains("a") || a.IndexOf("b") != 0 || len(a.Unique()) != 2 {
+		t.Fatalf("case-insensitive helpers: %v", a)
+	}
+	sort.Stable(a)
+	if a.String() != "A,a,b,B" || a.GormDataType() != "citext[]" {
+		t.Fatalf("sort: %v", a)
+	}
+	if b, _ := json.Marshal(c); string(b) != `"Alice@Example.com"` {
+		t.Fatalf("json: %s", b)
+	}
+}
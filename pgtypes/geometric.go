@@ -0,0 +1,455 @@
+package pgtypes
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Point is a PostgreSQL point, (x,y). It is also the point shape of PostGIS geometries.
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Line is a PostgreSQL line, the infinite line Ax + By + C = 0 written {A,B,C}.
+type Line struct {
+	A float64 `json:"a"`
+	B float64 `json:"b"`
+	C float64 `json:"c"`
+}
+
+// LSeg is a PostgreSQL lseg, the line segment [(x1,y1),(x2,y2)].
+type LSeg [2]Point
+
+// Box is a PostgreSQL box. PostgreSQL reorders the corners so that High is the upper right and
+// Low the lower left one.
+type Box struct {
+	High Point `json:"high"`
+	Low  Point `json:"low"`
+}
+
+// Path is a PostgreSQL path: an open [(x1,y1),...] or a closed ((x1,y1),...) list of points.
+type Path struct {
+	Points []Point `json:"points"`
+	Closed bool    `json:"closed"`
+}
+
+// Polygon is a list of rings, the first being the exterior and any others holes. A PostgreSQL
+// polygon ((x1,y1),...) has exactly one ring; a PostGIS polygon may have several.
+type Polygon [][]Point
+
+// Circle is a PostgreSQL circle, <(x,y),r>.
+type Circle struct {
+	Center Point   `json:"center"`
+	Radius float64 `json:"radius"`
+}
+
+// geometricNumbers returns the numbers of a geometric literal, ignoring its brackets.
+func geometricNumbers(s, typeName string) ([]float64, error) {
+	fields := strings.Split(strings.Map(func(r rune) rune {
+		if strings.ContainsRune("()[]{}<>", r) {
+			return ' '
+		}
+		return r
+	}, s), ",")
+	nums := make([]float64, len(fields))
+	for i, f := range fields {
+		n, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", typeName, s)
+		}
+		nums[i] = n
+	}
+	return nums, nil
+}
+
+// geometricPoints returns the points of a geometric literal.
+func geometricPoints(s, typeName string) ([]Point, error) {
+	nums, err := geometricNumbers(s, typeName)
+	if err != nil {
+		return nil, err
+	}
+	if len(nums)%2 != 0 {
+		return nil, fmt.Errorf("invalid %s %q: odd number of coordinates", typeName, s)
+	}
+	points := make([]Point, len(nums)/2)
+	for i := range points {
+		points[i] = Point{nums[2*i], nums[2*i+1]}
+	}
+	return points, nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func formatPoints(b *strings.Builder, points []Point) {
+	for i, p := range points {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(p.String())
+	}
+}
+
+// geometricText returns the literal in src, a string or []byte, for scanning into typeName.
+func geometricText(src interface{}, typeName string) (string, error) {
+	switch v := src.(type) {
+	case []byte:
+		return string(v), nil
+	case string:
+		return v, nil
+	}
+	return "", fmt.Errorf("cannot scan type %T into %s", src, typeName)
+}
+
+func geometricDBDataType(db *gorm.DB, typeName string) string {
+	if db.Dialector.Name() == "postgres" {
+		return typeName
+	}
+	return ""
+}
+
+// ParsePoint parses (x,y) or x,y.
+func ParsePoint(s string) (Point, error) {
+	points, err := geometricPoints(s, "point")
+	if err != nil {
+		return Point{}, err
+	}
+	if len(points) != 1 {
+		return Point{}, fmt.Errorf("invalid point %q", s)
+	}
+	return points[0], nil
+}
+
+// Distance returns the Euclidean distance to other, as PostgreSQL's <->.
+func (p Point) Distance(other Point) float64 {
+	return math.Hypot(p.X-other.X, p.Y-other.Y)
+}
+
+func (p Point) String() string {
+	return "(" + formatFloat(p.X) + "," + formatFloat(p.Y) + ")"
+}
+
+func (p *Point) Scan(src interface{}) error {
+	if src == nil {
+		*p = Point{}
+		return nil
+	}
+	s, err := geometricText(src, "Point")
+	if err != nil {
+		return err
+	}
+	*p, err = ParsePoint(s)
+	return err
+}
+
+func (p Point) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+func (Point) GormDataType() string { return "point" }
+
+func (Point) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return geometricDBDataType(db, "point")
+}
+
+// ParseLine parses {A,B,C}. The two-point form [(x1,y1),(x2,y2)] is also accepted.
+func ParseLine(s string) (Line, error) {
+	nums, err := geometricNumbers(s, "line")
+	if err != nil {
+		return Line{}, err
+	}
+	switch {
+	case len(nums) == 3 && strings.HasPrefix(strings.TrimSpace(s), "{"):
+		if nums[0] == 0 && nums[1] == 0 {
+			return Line{}, fmt.Errorf("invalid line %q: A and B cannot both be zero", s)
+		}
+		return Line{nums[0], nums[1], nums[2]}, nil
+	case len(nums) == 4:
+		p, q := Point{nums[0], nums[1]}, Point{nums[2], nums[3]}
+		if p == q {
+			return Line{}, fmt.Errorf("invalid line %q: the points must be distinct", s)
+		}
+		a, b := q.Y-p.Y, p.X-q.X
+		return Line{a, b, -(a*p.X + b*p.Y)}, nil
+	}
+	return Line{}, fmt.Errorf("invalid line %q", s)
+}
+
+func (l Line) String() string {
+	return "{" + formatFloat(l.A) + "," + formatFloat(l.B) + "," + formatFloat(l.C) + "}"
+}
+
+func (l *Line) Scan(src interface{}) error {
+	if src == nil {
+		*l = Line{}
+		return nil
+	}
+	s, err := geometricText(src, "Line")
+	if err != nil {
+		return err
+	}
+	*l, err = ParseLine(s)
+	return err
+}
+
+func (l Line) Value() (driver.Value, error) {
+	return l.String(), nil
+}
+
+func (Line) GormDataType() string { return "line" }
+
+func (Line) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return geometricDBDataType(db, "line")
+}
+
+// ParseLSeg parses [(x1,y1),(x2,y2)].
+func ParseLSeg(s string) (LSeg, error) {
+	points, err := geometricPoints(s, "lseg")
+	if err != nil {
+		return LSeg{}, err
+	}
+	if len(points) != 2 {
+		return LSeg{}, fmt.Errorf("invalid lseg %q", s)
+	}
+	return LSeg{points[0], points[1]}, nil
+}
+
+// Length returns the distance between the end points.
+func (l LSeg) Length() float64 {
+	return l[0].Distance(l[1])
+}
+
+func (l LSeg) String() string {
+	return "[" + l[0].String() + "," + l[1].String() + "]"
+}
+
+func (l *LSeg) Scan(src interface{}) error {
+	if src == nil {
+		*l = LSeg{}
+		return nil
+	}
+	s, err := geometricText(src, "LSeg")
+	if err != nil {
+		return err
+	}
+	*l, err = ParseLSeg(s)
+	return err
+}
+
+func (l LSeg) Value() (driver.Value, error) {
+	return l.String(), nil
+}
+
+func (LSeg) GormDataType() string { return "lseg" }
+
+func (LSeg) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return geometricDBDataType(db, "lseg")
+}
+
+// NewBox returns the box with the given opposite corners, reordered like PostgreSQL does.
+func NewBox(a, b Point) Box {
+	return Box{
+		High: Point{math.Max(a.X, b.X), math.Max(a.Y, b.Y)},
+		Low:  Point{math.Min(a.X, b.X), math.Min(a.Y, b.Y)},
+	}
+}
+
+// ParseBox parses (x1,y1),(x2,y2), the corners in any order.
+func ParseBox(s string) (Box, error) {
+	points, err := geometricPoints(s, "box")
+	if err != nil {
+		return Box{}, err
+	}
+	if len(points) != 2 {
+		return Box{}, fmt.Errorf("invalid box %q", s)
+	}
+	return NewBox(points[0], points[1]), nil
+}
+
+// Contains reports whether p lies within the box or on its boundary, as PostgreSQL's b @> p.
+func (b Box) Contains(p Point) bool {
+	return p.X >= b.Low.X && p.X <= b.High.X && p.Y >= b.Low.Y && p.Y <= b.High.Y
+}
+
+func (b Box) String() string {
+	return b.High.String() + "," + b.Low.String()
+}
+
+func (b *Box) Scan(src interface{}) error {
+	if src == nil {
+		*b = Box{}
+		return nil
+	}
+	s, err := geometricText(src, "Box")
+	if err != nil {
+		return err
+	}
+	*b, err = ParseBox(s)
+	return err
+}
+
+func (b Box) Value() (driver.Value, error) {
+	return NewBox(b.High, b.Low).String(), nil
+}
+
+func (Box) GormDataType() string { return "box" }
+
+func (Box) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return geometricDBDataType(db, "box")
+}
+
+// ParsePath parses an open [(x1,y1),...] or a closed ((x1,y1),...) path.
+func ParsePath(s string) (Path, error) {
+	points, err := geometricPoints(s, "path")
+	if err != nil {
+		return Path{}, err
+	}
+	if len(points) == 0 {
+		return Path{}, fmt.Errorf("invalid path %q", s)
+	}
+	return Path{Points: points, Closed: !strings.HasPrefix(strings.TrimSpace(s), "[")}, nil
+}
+
+func (p Path) String() string {
+	var b strings.Builder
+	open, end := byte('('), byte(')')
+	if !p.Closed {
+		open, end = '[', ']'
+	}
+	b.WriteByte(open)
+	formatPoints(&b, p.Points)
+	b.WriteByte(end)
+	return b.String()
+}
+
+func (p *Path) Scan(src interface{}) error {
+	if src == nil {
+		*p = Path{}
+		return nil
+	}
+	s, err := geometricText(src, "Path")
+	if err != nil {
+		return err
+	}
+	*p, err = ParsePath(s)
+	return err
+}
+
+func (p Path) Value() (driver.Value, error) {
+	if len(p.Points) == 0 {
+		return nil, fmt.Errorf("a path needs at least one point")
+	}
+	return p.String(), nil
+}
+
+func (Path) GormDataType() string { return "path" }
+
+func (Path) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return geometricDBDataType(db, "path")
+}
+
+// ParsePolygon parses a PostgreSQL polygon ((x1,y1),...) into a single-ring Polygon.
+func ParsePolygon(s string) (Polygon, error) {
+	points, err := geometricPoints(s, "polygon")
+	if err != nil {
+		return nil, err
+	}
+	if len(points) == 0 {
+		return nil, fmt.Errorf("invalid polygon %q", s)
+	}
+	return Polygon{points}, nil
+}
+
+// String returns the PostgreSQL polygon text of the exterior ring.
+func (p Polygon) String() string {
+	var b strings.Builder
+	b.WriteByte('(')
+	if len(p) > 0 {
+		formatPoints(&b, p[0])
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+func (p *Polygon) Scan(src interface{}) error {
+	if src == nil {
+		*p = nil
+		return nil
+	}
+	s, err := geometricText(src, "Polygon")
+	if err != nil {
+		return err
+	}
+	*p, err = ParsePolygon(s)
+	return err
+}
+
+// Value writes a PostgreSQL polygon, which cannot have holes: a nil Polygon is NULL and one with
+// several rings is an error.
+func (p Polygon) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	if len(p) != 1 || len(p[0]) == 0 {
+		return nil, fmt.Errorf("a PostgreSQL polygon has one non-empty ring, got %d rings", len(p))
+	}
+	return p.String(), nil
+}
+
+func (Polygon) GormDataType() string { return "polygon" }
+
+func (Polygon) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return geometricDBDataType(db, "polygon")
+}
+
+// ParseCircle parses <(x,y),r>.
+func ParseCircle(s string) (Circle, error) {
+	nums, err := geometricNumbers(s, "circle")
+	if err != nil {
+		return Circle{}, err
+	}
+	if len(nums) != 3 || nums[2] < 0 {
+		return Circle{}, fmt.Errorf("invalid circle %q", s)
+	}
+	return Circle{Center: Point{nums[0], nums[1]}, Radius: nums[2]}, nil
+}
+
+// Contains reports whether p lies within the circle or on its boundary, as PostgreSQL's c @> p.
+func (c Circle) Contains(p Point) bool {
+	return c.Center.Distance(p) <= c.Radius
+}
+
+func (c Circle) String() string {
+	return "<" + c.Center.String() + "," + formatFloat(c.Radius) + ">"
+}
+
+func (c *Circle) Scan(src interface{}) error {
+	if src == nil {
+		*c = Circle{}
+		return nil
+	}
+	s, err := geometricText(src, "Circle")
+	if err != nil {
+		return err
+	}
+	*c, err = ParseCircle(s)
+	return err
+}
+
+func (c Circle) Value() (driver.Value, error) {
+	return c.String(), nil
+}
+
+func (Circle) GormDataType() string { return "circle" }
+
+func (Circle) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return geometricDBDataType(db, "circle")
+}
@@ -0,0 +1,502 @@
+package pgtypes
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Shape is the shape of a PostGIS geometry: a Point, LineString, Polygon, MultiPoint,
+// MultiLineString, MultiPolygon or GeometryCollection. Only 2D shapes are supported.
+type Shape interface {
+	wkbType() uint32
+}
+
+// PostGIS shapes besides Point and Polygon, which they share with the built-in geometric types.
+type (
+	LineString         []Point
+	MultiPoint         []Point
+	MultiLineString    []LineString
+	MultiPolygon       []Polygon
+	GeometryCollection []Shape
+)
+
+func (Point) wkbType() uint32              { return 1 }
+func (LineString) wkbType() uint32         { return 2 }
+func (Polygon) wkbType() uint32            { return 3 }
+func (MultiPoint) wkbType() uint32         { return 4 }
+func (MultiLineString) wkbType() uint32    { return 5 }
+func (MultiPolygon) wkbType() uint32       { return 6 }
+func (GeometryCollection) wkbType() uint32 { return 7 }
+
+// Geometry is a PostGIS geometry or geography value of shape T with its spatial reference
+// system. Columns constrained to a shape, such as geometry(Point,4326), use that shape;
+// others use Geometry[Shape], whose nil Shape is NULL. Values are read and written as
+// (extended) well-known binary, and marshal to JSON as GeoJSON geometries.
+type Geometry[T Shape] struct {
+	SRID  int
+	Shape T
+}
+
+// EWKB flags of the geometry type.
+const (
+	ewkbZ    = 0x80000000
+	ewkbM    = 0x40000000
+	ewkbSRID = 0x20000000
+)
+
+// ParseEWKB decodes a geometry in WKB or PostGIS's EWKB, which adds the SRID. The SRID is 0
+// when the input has none.
+func ParseEWKB(b []byte) (Shape, int, error) {
+	r := &wkbReader{b: b}
+	shape, srid, err := r.geometry()
+	if err == nil && r.i != len(b) {
+		err = fmt.Errorf("junk after geometry")
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid WKB: %w", err)
+	}
+	return shape, srid, nil
+}
+
+type wkbReader struct {
+	b     []byte
+	i     int
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) uint32() (uint32, error) {
+	if len(r.b)-r.i < 4 {
+		return 0, fmt.Errorf("unexpected end of input")
+	}
+	v := r.order.Uint32(r.b[r.i:])
+	r.i += 4
+	return v, nil
+}
+
+func (r *wkbReader) point() (Point, error) {
+	if len(r.b)-r.i < 16 {
+		return Point{}, fmt.Errorf("unexpected end of input")
+	}
+	p := Point{
+		X: math.Float64frombits(r.order.Uint64(r.b[r.i:])),
+		Y: math.Float64frombits(r.order.Uint64(r.b[r.i+8:])),
+	}
+	r.i += 16
+	return p, nil
+}
+
+func (r *wkbReader) points() ([]Point, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	if int(n) > (len(r.b)-r.i)/16 {
+		return nil, fmt.Errorf("unexpected end of input")
+	}
+	points := make([]Point, n)
+	for i := range points {
+		if points[i], err = r.point(); err != nil {
+			return nil, err
+		}
+	}
+	return points, nil
+}
+
+func (r *wkbReader) polygon() (Polygon, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	if int(n) > (len(r.b)-r.i)/4 {
+		return nil, fmt.Errorf("unexpected end of input")
+	}
+	rings := make(Polygon, n)
+	for i := range rings {
+		if rings[i], err = r.points(); err != nil {
+			return nil, err
+		}
+	}
+	return rings, nil
+}
+
+// geometry reads a geometry with its header: byte order, type and optional SRID.
+func (r *wkbReader) geometry() (Shape, int, error) {
+	if r.i >= len(r.b) {
+		return nil, 0, fmt.Errorf("unexpected end of input")
+	}
+	switch r.b[r.i] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return nil, 0, fmt.Errorf("invalid byte order %d", r.b[r.i])
+	}
+	r.i++
+	typ, err := r.uint32()
+	if err != nil {
+		return nil, 0, err
+	}
+	base := typ &^ (ewkbZ | ewkbM | ewkbSRID)
+	if typ&(ewkbZ|ewkbM) != 0 || base > 1000 {
+		return nil, 0, fmt.Errorf("only 2D geometries are supported")
+	}
+	srid := 0
+	if typ&ewkbSRID != 0 {
+		s, err := r.uint32()
+		if err != nil {
+			return nil, 0, err
+		}
+		srid = int(int32(s))
+	}
+	var shape Shape
+	switch base {
+	case 1:
+		shape, err = r.point()
+	case 2:
+		var points []Point
+		points, err = r.points()
+		shape = LineString(points)
+	case 3:
+		shape, err = r.polygon()
+	case 4, 5, 6, 7:
+		shape, err = r.collection(base)
+	default:
+		err = fmt.Errorf("unsupported geometry type %d", base)
+	}
+	return shape, srid, err
+}
+
+// collection reads the members of a multi geometry or collection, each with its own header.
+func (r *wkbReader) collection(typ uint32) (Shape, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	if int(n) > (len(r.b)-r.i)/5 {
+		return nil, fmt.Errorf("unexpected end of input")
+	}
+	members := make([]Shape, n)
+	for i := range members {
+		if members[i], _, err = r.geometry(); err != nil {
+			return nil, err
+		}
+		if typ != 7 && members[i].wkbType() != typ-3 {
+			return nil, fmt.Errorf("unexpected %T in a multi geometry of type %d", members[i], typ)
+		}
+	}
+	switch typ {
+	case 4:
+		out := make(MultiPoint, n)
+		for i, m := range members {
+			out[i] = m.(Point)
+		}
+		return out, nil
+	case 5:
+		out := make(MultiLineString, n)
+		for i, m := range members {
+			out[i] = m.(LineString)
+		}
+		return out, nil
+	case 6:
+		out := make(MultiPolygon, n)
+		for i, m := range members {
+			out[i] = m.(Polygon)
+		}
+		return out, nil
+	}
+	return GeometryCollection(members), nil
+}
+
+// FormatEWKB encodes a geometry as little-endian EWKB, with the SRID when it is not 0.
+func FormatEWKB(shape Shape, srid int) []byte {
+	return appendWKB(nil, shape, srid)
+}
+
+func appendWKB(b []byte, shape Shape, srid int) []byte {
+	typ := shape.wkbType()
+	if srid != 0 {
+		typ |= ewkbSRID
+	}
+	b = append(b, 1)
+	b = binary.LittleEndian.AppendUint32(b, typ)
+	if srid != 0 {
+		b = binary.LittleEndian.AppendUint32(b, uint32(int32(srid)))
+	}
+	appendPoints := func(b []byte, points []Point) []byte {
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(points)))
+		for _, p := range points {
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(p.X))
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(p.Y))
+		}
+		return b
+	}
+	switch s := shape.(type) {
+	case Point:
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(s.X))
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(s.Y))
+	case LineString:
+		b = appendPoints(b, s)
+	case Polygon:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(s)))
+		for _, ring := range s {
+			b = appendPoints(b, ring)
+		}
+	case MultiPoint:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(s)))
+		for _, m := range s {
+			b = appendWKB(b, m, 0)
+		}
+	case MultiLineString:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(s)))
+		for _, m := range s {
+			b = appendWKB(b, m, 0)
+		}
+	case MultiPolygon:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(s)))
+		for _, m := range s {
+			b = appendWKB(b, m, 0)
+		}
+	case GeometryCollection:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(s)))
+		for _, m := range s {
+			b = appendWKB(b, m, 0)
+		}
+	}
+	return b
+}
+
+// Scan reads hex-encoded EWKB, as PostGIS writes geometries in text, or binary WKB.
+func (g *Geometry[T]) Scan(src interface{}) error {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		*g = Geometry[T]{}
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into %T", src, *g)
+	}
+	if len(b) > 0 && b[0] != 0 && b[0] != 1 {
+		decoded, err := hex.DecodeString(string(b))
+		if err != nil {
+			return fmt.Errorf("cannot scan %q into %T: %w", b, *g, err)
+		}
+		b = decoded
+	}
+	shape, srid, err := ParseEWKB(b)
+	if err != nil {
+		return err
+	}
+	t, ok := shape.(T)
+	if !ok {
+		return fmt.Errorf("cannot scan a %T into %T", shape, *g)
+	}
+	*g = Geometry[T]{SRID: srid, Shape: t}
+	return nil
+}
+
+// Value writes hex-encoded EWKB. A Geometry[Shape] without a shape is NULL.
+func (g Geometry[T]) Value() (driver.Value, error) {
+	if Shape(g.Shape) == nil {
+		return nil, nil
+	}
+	return hex.EncodeToString(FormatEWKB(g.Shape, g.SRID)), nil
+}
+
+// MarshalJSON writes the shape as a GeoJSON geometry. GeoJSON has no SRID.
+func (g Geometry[T]) MarshalJSON() ([]byte, error) {
+	if Shape(g.Shape) == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(geoJSON(g.Shape))
+}
+
+// UnmarshalJSON reads a GeoJSON geometry and sets the SRID to 4326, GeoJSON's coordinate
+// reference system.
+func (g *Geometry[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*g = Geometry[T]{}
+		return nil
+	}
+	shape, err := parseGeoJSON(data)
+	if err != nil {
+		return err
+	}
+	t, ok := shape.(T)
+	if !ok {
+		return fmt.Errorf("cannot unmarshal a %T into %T", shape, *g)
+	}
+	*g = Geometry[T]{SRID: 4326, Shape: t}
+	return nil
+}
+
+func (Geometry[T]) GormDataType() string {
+	return "geometry"
+}
+
+func (Geometry[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "geometry"
+	}
+	return ""
+}
+
+type geoJSONGeometry struct {
+	Type        string            `json:"type"`
+	Coordinates any               `json:"coordinates,omitempty"`
+	Geometries  []geoJSONGeometry `json:"geometries,omitempty"`
+}
+
+func geoJSONPoints(points []Point) [][2]float64 {
+	out := make([][2]float64, len(points))
+	for i, p := range points {
+		out[i] = [2]float64{p.X, p.Y}
+	}
+	return out
+}
+
+func geoJSONPolygon(p Polygon) [][][2]float64 {
+	out := make([][][2]float64, len(p))
+	for i, ring := range p {
+		out[i] = geoJSONPoints(ring)
+	}
+	return out
+}
+
+func geoJSON(shape Shape) geoJSONGeometry {
+	switch s := shape.(type) {
+	case Point:
+		return geoJSONGeometry{Type: "Point", Coordinates: [2]float64{s.X, s.Y}}
+	case LineString:
+		return geoJSONGeometry{Type: "LineString", Coordinates: geoJSONPoints(s)}
+	case Polygon:
+		return geoJSONGeometry{Type: "Polygon", Coordinates: geoJSONPolygon(s)}
+	case MultiPoint:
+		return geoJSONGeometry{Type: "MultiPoint", Coordinates: geoJSONPoints(s)}
+	case MultiLineString:
+		lines := make([][][2]float64, len(s))
+		for i, l := range s {
+			lines[i] = geoJSONPoints(l)
+		}
+		return geoJSONGeometry{Type: "MultiLineString", Coordinates: lines}
+	case MultiPolygon:
+		polygons := make([][][][2]float64, len(s))
+		for i, p := range s {
+			polygons[i] = geoJSONPolygon(p)
+		}
+		return geoJSONGeometry{Type: "MultiPolygon", Coordinates: polygons}
+	}
+	members := shape.(GeometryCollection)
+	g := geoJSONGeometry{Type: "GeometryCollection", Geometries: make([]geoJSONGeometry, len(members))}
+	for i, m := range members {
+		g.Geometries[i] = geoJSON(m)
+	}
+	return g
+}
+
+func parseGeoJSON(data []byte) (Shape, error) {
+	var g struct {
+		Type        string            `json:"type"`
+		Coordinates json.RawMessage   `json:"coordinates"`
+		Geometries  []json.RawMessage `json:"geometries"`
+	}
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, err
+	}
+	toPoints := func(coords [][]float64) ([]Point, error) {
+		points := make([]Point, len(coords))
+		for i, c := range coords {
+			if len(c) != 2 {
+				return nil, fmt.Errorf("GeoJSON position %v is not 2D", c)
+			}
+			points[i] = Point{c[0], c[1]}
+		}
+		return points, nil
+	}
+	toPolygon := func(coords [][][]float64) (Polygon, error) {
+		p := make(Polygon, len(coords))
+		for i, ring := range coords {
+			var err error
+			if p[i], err = toPoints(ring); err != nil {
+				return nil, err
+			}
+		}
+		return p, nil
+	}
+	switch g.Type {
+	case "Point":
+		var c []float64
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+			return nil, err
+		}
+		points, err := toPoints([][]float64{c})
+		if err != nil {
+			return nil, err
+		}
+		return points[0], nil
+	case "LineString", "MultiPoint":
+		var c [][]float64
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+			return nil, err
+		}
+		points, err := toPoints(c)
+		if g.Type == "MultiPoint" {
+			return MultiPoint(points), err
+		}
+		return LineString(points), err
+	case "Polygon":
+		var c [][][]float64
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+			return nil, err
+		}
+		return toPolygon(c)
+	case "MultiLineString":
+		var c [][][]float64
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+			return nil, err
+		}
+		lines := make(MultiLineString, len(c))
+		for i, l := range c {
+			points, err := toPoints(l)
+			if err != nil {
+				return nil, err
+			}
+			lines[i] = points
+		}
+		return lines, nil
+	case "MultiPolygon":
+		var c [][][][]float64
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+			return nil, err
+		}
+		polygons := make(MultiPolygon, len(c))
+		for i, p := range c {
+			var err error
+			if polygons[i], err = toPolygon(p); err != nil {
+				return nil, err
+			}
+		}
+		return polygons, nil
+	case "GeometryCollection":
+		members := make(GeometryCollection, len(g.Geometries))
+		for i, m := range g.Geometries {
+			var err error
+			if members[i], err = parseGeoJSON(m); err != nil {
+				return nil, err
+			}
+		}
+		return members, nil
+	}
+	return nil, fmt.Errorf("unsupported GeoJSON geometry type %q", g.Type)
+}
@@ -0,0 +1,152 @@
+package pgtypes
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestGeometricTypes(t *testing.T) {
+	var p Point
+	if err := p.Scan([]byte("(1.5,-2)")); err != nil || p != (Point{1.5, -2}) {
+		t.Fatalf("point: %v (%v)", p, err)
+	}
+	var l Line
+	if err := l.Scan("{1,-1,0}"); err != nil || l.String() != "{1,-1,0}" {
+		t.Fatalf("line: %v (%v)", l, err)
+	}
+	if l, err := ParseLine("[(0,0),(1,1)]"); err != nil || l.A != 1 || l.B != -1 || l.C != 0 {
+		t.Fatalf("line from points: %v (%v)", l, err)
+	}
+	var s LSeg
+	if err := s.Scan("[(0,0),(3,4)]"); err != nil || s.Length() != 5 {
+		t.Fatalf("lseg: %v (%v)", s, err)
+	}
+	var b Box
+	if err := b.Scan("(0,0),(2,3)"); err != nil || b.String() != "(2,3),(0,0)" || !b.Contains(Point{1, 1}) || b.Contains(Point{3, 1}) {
+		t.Fatalf("box: %v (%v)", b, err)
+	}
+	var path Path
+	if err := path.Scan("[(0,0),(1,1),(2,0)]"); err != nil || path.Closed || len(path.Points) != 3 {
+		t.Fatalf("open path: %v (%v)", path, err)
+	}
+	if err := path.Scan("((0,0),(1,1))"); err != nil || !path.Closed || path.String() != "((0,0),(1,1))" {
+		t.Fatalf("closed path: %v (%v)", path, err)
+	}
+	var poly Polygon
+	if err := poly.Scan("((0,0),(0,1),(1,0))"); err != nil || len(poly) != 1 || len(poly[0]) != 3 {
+		t.Fatalf("polygon: %v (%v)", poly, err)
+	}
+	if v, _ := poly.Value(); v != "((0,0),(0,1),(1,0))" {
+		t.Fatalf("polygon value: %v", v)
+	}
+	if _, err := append(poly, poly[0]).Value(); err == nil {
+		t.Fatal("expected error for a polygon with a hole")
+	}
+	var c Circle
+	if err := c.Scan("<(1,1),2>"); err != nil || !c.Contains(Point{2, 2}) || c.Contains(Point{3, 3}) || c.String() != "<(1,1),2>" {
+		t.Fatalf("circle: %v (%v)", c, err)
+	}
+	for _, bad := range []string{"(1,2,3)", "(a,b)", "()"} {
+		if _, err := ParsePoint(bad); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+	var points Array[Point]
+	if err := points.Scan(`{"(1,2)","(3,4)"}`); err != nil || points[1].Y != 4 {
+		t.Fatalf("point[]: %v (%v)", points, err)
+	}
+	if v, _ := points.Value(); v != `{"(1,2)","(3,4)"}` || points.GormDataType() != "point[]" {
+		t.Fatalf("point[] value: %v", v)
+	}
+
+	var boxes Array[Box]
+	if err := boxes.Scan("{(1,1),(0,0);(3,3),(2,2)}"); err != nil || len(boxes) != 2 || boxes[1] != NewBox(Point{2, 2}, Point{3, 3}) {
+		t.Fatalf("box[]: %v (%v)", boxes, err)
+	}
+	if v, _ := boxes.Value(); v != "{(1,1),(0,0);(3,3),(2,2)}" || boxes.GormDataType() != "box[]" {
+		t.Fatalf("box[] value: %v", v)
+	}
+	var nullBoxes NullableArray[Box]
+	if err := nullBoxes.Scan("{(1,1),(0,0);NULL}"); err != nil || len(nullBoxes) != 2 || nullBoxes[1] != nil {
+		t.Fatalf("box[] with NULL: %v (%v)", nullBoxes, err)
+	}
+	var grid Array2D[Box]
+	if err := grid.Scan("{{(1,1),(0,0)};{(2,2),(1,1)}}"); err != nil {
+		t.Fatalf("box[][]: %v (%v)", grid, err)
+	}
+	if v, _ := grid.Value(); v != "{{(1,1),(0,0)};{(2,2),(1,1)}}" {
+		t.Fatalf("box[][] value: %v", v)
+	}
+}
+
+func TestGeometry_EWKB(t *testing.T) {
+	// SELECT 'SRID=4326;POINT(1 2)'::geometry
+	const point = "0101000020e6100000000000000000f03f0000000000000040"
+	var g Geometry[Point]
+	if err := g.Scan([]byte(point)); err != nil || g.SRID != 4326 || g.Shape != (Point{1, 2}) {
+		t.Fatalf("scan: %+v (%v)", g, err)
+	}
+	if v, _ := g.Value(); v != point {
+		t.Fatalf("value: %v", v)
+	}
+	// Big-endian WKB without SRID: POINT(1 2)
+	if err := g.Scan("00000000013ff00000000000004000000000000000"); err != nil || g.SRID != 0 || g.Shape != (Point{1, 2}) {
+		t.Fatalf("big-endian: %+v (%v)", g, err)
+	}
+	var poly Geometry[Polygon]
+	if err := poly.Scan(point); err == nil || !strings.Contains(err.Error(), "cannot scan a pgtypes.Point") {
+		t.Fatalf("expected shape mismatch, got %v", err)
+	}
+	// SELECT 'SRID=4326;POINT Z(1 2 3)'::geometry
+	if err := g.Scan("01010000a0e6100000000000000000f03f00000000000000400000000000000840"); err == nil || !strings.Contains(err.Error(), "2D") {
+		t.Fatalf("expected 2D error, got %v", err)
+	}
+
+	square := Polygon{{{0, 0}, {4, 0}, {4, 4}, {0, 0}}, {{1, 1}, {2, 1}, {2, 2}, {1, 1}}}
+	for _, shape := range []Shape{
+		LineString{{0, 0}, {1, 1}},
+		square,
+		MultiPoint{{1, 2}, {3, 4}},
+		MultiLineString{{{0, 0}, {1, 1}}, {{2, 2}, {3, 3}}},
+		MultiPolygon{square, square[:1]},
+		GeometryCollection{Point{1, 2}, LineString{{0, 0}, {1, 1}}},
+	} {
+		var got Geometry[Shape]
+		v, err := Geometry[Shape]{SRID: 3857, Shape: shape}.Value()
+		if err == nil {
+			err = got.Scan(v)
+		}
+		if err != nil || got.SRID != 3857 || !equalValues(got.Shape, shape) {
+			t.Errorf("%T roundtrip: %+v (%v)", shape, got, err)
+		}
+	}
+	if v, _ := (Geometry[Shape]{}).Value(); v != nil {
+		t.Fatalf("nil shape value: %v", v)
+	}
+	if _, _, err := ParseEWKB([]byte{1, 1, 0, 0, 0, 0}); err == nil {
+		t.Fatal("expected error for truncated WKB")
+	}
+}
+
+func TestGeometry_GeoJSON(t *testing.T) {
+	b, err := json.Marshal(Geometry[Point]{SRID: 4326, Shape: Point{1.5, 2}})
+	if err != nil || string(b) != `{"type":"Point","coordinates":[1.5,2]}` {
+		t.Fatalf("point: %s (%v)", b, err)
+	}
+	var poly Geometry[Shape]
+	in := `{"type":"GeometryCollection","geometries":[{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]},{"type":"MultiPoint","coordinates":[[1,2]]}]}`
+	if err := json.Unmarshal([]byte(in), &poly); err != nil || poly.SRID != 4326 {
+		t.Fatalf("unmarshal: %+v (%v)", poly, err)
+	}
+	if b, _ := json.Marshal(poly); string(b) != in {
+		t.Fatalf("roundtrip: %s", b)
+	}
+	var p Geometry[Point]
+	if err := json.Unmarshal([]byte(`{"type":"LineString","coordinates":[[0,0],[1,1]]}`), &p); err == nil {
+		t.Fatal("expected shape mismatch")
+	}
+	if err := json.Unmarshal([]byte(`{"type":"Point","coordinates":[1,2,3]}`), &p); err == nil {
+		t.Fatal("expected error for a 3D position")
+	}
+}
@@ -0,0 +1,199 @@
+package pgtypes
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Hstore is a value of the hstore extension type: a set of string keys with string or NULL
+// values. A nil value is a NULL in the hstore; a nil Hstore is stored as SQL NULL.
+type Hstore map[string]*string
+
+// HstoreArray is a PostgreSQL hstore[].
+type HstoreArray = Array[Hstore]
+
+// NewHstore returns an Hstore holding the given non-NULL values.
+func NewHstore(values map[string]string) Hstore {
+	h := make(Hstore, len(values))
+	for k, v := range values {
+		h[k] = &v
+	}
+	return h
+}
+
+// ParseHstore parses the hstore text format, such as "a"=>"1", b=>NULL. Keys and values may be
+// double-quoted, with backslash escaping any character; an unquoted NULL value is NULL.
+func ParseHstore(s string) (Hstore, error) {
+	h := Hstore{}
+	p := &hstoreParser{s: s}
+	for {
+		p.skipSpace()
+		if p.i == len(s) {
+			return h, nil
+		}
+		key, _, err := p.token("=")
+		if err != nil {
+			return nil, fmt.Errorf("invalid hstore %q: %w", s, err)
+		}
+		p.skipSpace()
+		if !strings.HasPrefix(s[p.i:], "=>") {
+			return nil, fmt.Errorf("invalid hstore %q: expected \"=>\" after key %q", s, key)
+		}
+		p.i += 2
+		p.skipSpace()
+		value, quoted, err := p.token(",")
+		if err != nil {
+			return nil, fmt.Errorf("invalid hstore %q: %w", s, err)
+		}
+		if !quoted && strings.EqualFold(value, "NULL") {
+			h[key] = nil
+		} else {
+			h[key] = &value
+		}
+		p.skipSpace()
+		switch {
+		case p.i == len(s):
+			return h, nil
+		case s[p.i] == ',':
+			p.i++
+		default:
+			return nil, fmt.Errorf("invalid hstore %q: unexpected %q", s, s[p.i])
+		}
+	}
+}
+
+type hstoreParser struct {
+	s string
+	i int
+}
+
+func (p *hstoreParser) skipSpace() {
+	for p.i < len(p.s) && isArraySpace(p.s[p.i]) {
+		p.i++
+	}
+}
+
+// token reads a quoted key or value, or an unquoted one ending at whitespace or a stop character.
+func (p *hstoreParser) token(stop string) (string, bool, error) {
+	var b strings.Builder
+	if p.i < len(p.s) && p.s[p.i] == '"' {
+		for p.i++; p.i < len(p.s) && p.s[p.i] != '"'; p.i++ {
+			if p.s[p.i] == '\\' {
+				p.i++
+				if p.i == len(p.s) {
+					break
+				}
+			}
+			b.WriteByte(p.s[p.i])
+		}
+		if p.i == len(p.s) {
+			return "", true, fmt.Errorf("unterminated quoted string")
+		}
+		p.i++
+		return b.String(), true, nil
+	}
+	for ; p.i < len(p.s) && !isArraySpace(p.s[p.i]) && strings.IndexByte(stop, p.s[p.i]) < 0; p.i++ {
+		if p.s[p.i] == '\\' {
+			p.i++
+			if p.i == len(p.s) {
+				return "", false, fmt.Errorf("trailing backslash")
+			}
+		}
+		b.WriteByte(p.s[p.i])
+	}
+	if b.Len() == 0 {
+		return "", false, fmt.Errorf("expected a key or value at offset %d", p.i)
+	}
+	return b.String(), false, nil
+}
+
+// Get returns the value of key; ok is false when the key is missing or its value is NULL.
+func (h Hstore) Get(key string) (value string, ok bool) {
+	if v := h[key]; v != nil {
+		return *v, true
+	}
+	return "", false
+}
+
+// Has reports whether key is present, even with a NULL value, as PostgreSQL's h ? key.
+func (h Hstore) Has(key string) bool {
+	_, ok := h[key]
+	return ok
+}
+
+// Keys returns the keys in sorted order.
+func (h Hstore) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// String returns the hstore text format, with the keys in sorted order.
+func (h Hstore) String() string {
+	var b strings.Builder
+	for i, k := range h.Keys() {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		writeHstoreString(&b, k)
+		b.WriteString("=>")
+		if v := h[k]; v != nil {
+			writeHstoreString(&b, *v)
+		} else {
+			b.WriteString("NULL")
+		}
+	}
+	return b.String()
+}
+
+func writeHstoreString(b *strings.Builder, s string) {
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('"')
+}
+
+func (h *Hstore) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*h = nil
+	case []byte:
+		*h, err = ParseHstore(string(v))
+	case string:
+		*h, err = ParseHstore(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into Hstore", src)
+	}
+	return err
+}
+
+func (h Hstore) Value() (driver.Value, error) {
+	if h == nil {
+		return nil, nil
+	}
+	return h.String(), nil
+}
+
+func (Hstore) GormDataType() string {
+	return "hstore"
+}
+
+func (Hstore) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "hstore"
+	}
+	return ""
+}
@@ -0,0 +1,612 @@
+package pgtypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// IntervalStyle is a value of PostgreSQL's IntervalStyle setting, which selects the output
+// format of intervals.
+type IntervalStyle string
+
+const (
+	IntervalStylePostgres        IntervalStyle = "postgres"         // 1 year 2 mons 3 days 04:05:06
+	IntervalStylePostgresVerbose IntervalStyle = "postgres_verbose" // @ 1 year 2 mons 3 days 4 hours 5 mins 6 secs
+	IntervalStyleSQLStandard     IntervalStyle = "sql_standard"     // +1-2 +3 +4:05:06
+	IntervalStyleISO8601         IntervalStyle = "iso_8601"         // P1Y2M3DT4H5M6S
+)
+
+const (
+	usecPerSecond = int64(time.Second / time.Microsecond)
+	usecPerMinute = 60 * usecPerSecond
+	usecPerHour   = 60 * usecPerMinute
+	usecPerDay    = 24 * usecPerHour
+)
+
+// Interval is a PostgreSQL interval. Like PostgreSQL it keeps months, days and microseconds
+// apart: a month has no fixed number of days, and a day has no fixed number of hours across
+// daylight saving changes. Each part carries its own sign.
+type Interval struct {
+	Months       int32
+	Days         int32
+	Microseconds int64
+}
+
+// IntervalFromDuration returns the interval of d, truncated to microseconds.
+func IntervalFromDuration(d time.Duration) Interval {
+	return Interval{Microseconds: d.Microseconds()}
+}
+
+// Duration converts the interval to a time.Duration, counting a month as 30 days and a day as
+// 24 hours as PostgreSQL's justify functions do. Use AddTo for calendar arithmetic.
+func (iv Interval) Duration() time.Duration {
+	days := int64(iv.Months)*30 + int64(iv.Days)
+	return time.Duration(days*usecPerDay+iv.Microseconds) * time.Microsecond
+}
+
+// AddTo adds the interval to t the way PostgreSQL adds an interval to a timestamp: months first,
+// then days in t's location, then the time part.
+func (iv Interval) AddTo(t time.Time) time.Time {
+	return t.AddDate(0, int(iv.Months), int(iv.Days)).Add(time.Duration(iv.Microseconds) * time.Microsecond)
+}
+
+func (iv Interval) IsZero() bool {
+	return iv == Interval{}
+}
+
+// Neg returns the interval with every part negated.
+func (iv Interval) Neg() Interval {
+	return Interval{Months: -iv.Months, Days: -iv.Days, Microseconds: -iv.Microseconds}
+}
+
+// String formats the interval in PostgreSQL's default postgres style.
+func (iv Interval) String() string {
+	return iv.Format(IntervalStylePostgres)
+}
+
+// Format formats the interval the way PostgreSQL outputs it under the given IntervalStyle.
+// ParseInterval reads every style back to the same interval.
+func (iv Interval) Format(style IntervalStyle) string {
+	year, mon := int64(iv.Months/12), int64(iv.Months%12)
+	mday := int64(iv.Days)
+	t := iv.Microseconds
+	hour := t / usecPerHour
+	t -= hour * usecPerHour
+	minute := t / usecPerMinute
+	t -= minute * usecPerMinute
+	sec := t / usecPerSecond
+	fsec := t - sec*usecPerSecond
+	timeNegative := iv.Microseconds < 0
+
+	var b strings.Builder
+	switch style {
+	case IntervalStyleISO8601:
+		if iv.IsZero() {
+			return "PT0S"
+		}
+		b.WriteByte('P')
+		for _, p := range []struct {
+			value int64
+			unit  byte
+		}{{year, 'Y'}, {mon, 'M'}, {mday, 'D'}} {
+			if p.value != 0 {
+				fmt.Fprintf(&b, "%d%c", p.value, p.unit)
+			}
+		}
+		if iv.Microseconds != 0 {
+			b.WriteByte('T')
+			if hour != 0 {
+				fmt.Fprintf(&b, "%dH", hour)
+			}
+			if minute != 0 {
+				fmt.Fprintf(&b, "%dM", minute)
+			}
+			if sec != 0 || fsec != 0 {
+				if timeNegative {
+					b.WriteByte('-')
+				}
+				writeIntervalSeconds(&b, sec, fsec, false)
+				b.WriteByte('S')
+			}
+		}
+
+	case IntervalStyleSQLStandard:
+		hasNegative := iv.Months < 0 || iv.Days < 0 || iv.Microseconds < 0
+		hasPositive := iv.Months > 0 || iv.Days > 0 || iv.Microseconds > 0
+		hasYearMonth := iv.Months != 0
+		hasDayTime := iv.Days != 0 || iv.Microseconds != 0
+		switch {
+		case !hasNegative && !hasPositive:
+			return "0"
+		case hasNegative && hasPositive || hasYearMonth && hasDayTime:
+			// Not expressible in the standard's format: every part gets an explicit sign.
+			fmt.Fprintf(&b, "%c%d-%d %c%d %c%d:%02d:", signChar(iv.Months < 0), abs64(year), abs64(mon),
+				signChar(mday < 0), abs64(mday), signChar(timeNegative), abs64(hour), abs64(minute))
+			writeIntervalSeconds(&b, sec, fsec, true)
+		default:
+			// A single leading sign applies to every part.
+			if hasNegative {
+				b.WriteByte('-')
+			}
+			switch {
+			case hasYearMonth:
+				fmt.Fprintf(&b, "%d-%d", abs64(year), abs64(mon))
+			case mday != 0:
+				fmt.Fprintf(&b, "%d %d:%02d:", abs64(mday), abs64(hour), abs64(minute))
+				writeIntervalSeconds(&b, sec, fsec, true)
+			default:
+				fmt.Fprintf(&b, "%d:%02d:", abs64(hour), abs64(minute))
+				writeIntervalSeconds(&b, sec, fsec, true)
+			}
+		}
+
+	case IntervalStylePostgresVerbose:
+		b.WriteByte('@')
+		isZero, isBefore := true, false
+		for _, p := range []struct {
+			value int64
+			unit  string
+		}{{year, "year"}, {mon, "mon"}, {mday, "day"}, {hour, "hour"}, {minute, "min"}} {
+			value := p.value
+			if value == 0 {
+				continue
+			}
+			if isZero {
+				isBefore, value = value < 0, abs64(value)
+			} else if isBefore {
+				value = -value
+			}
+			fmt.Fprintf(&b, " %d %s", value, p.unit)
+			if value != 1 {
+				b.WriteByte('s')
+			}
+			isZero = false
+		}
+		if sec != 0 || fsec != 0 {
+			b.WriteByte(' ')
+			if timeNegative {
+				if isZero {
+					isBefore = true
+				} else if !isBefore {
+					b.WriteByte('-')
+				}
+			} else if isBefore {
+				b.WriteByte('-')
+			}
+			writeIntervalSeconds(&b, sec, fsec, false)
+			b.WriteString(" sec")
+			if abs64(sec) != 1 || fsec != 0 {
+				b.WriteByte('s')
+			}
+			isZero = false
+		}
+		if isZero {
+			b.WriteString(" 0")
+		}
+		if isBefore {
+			b.WriteString(" ago")
+		}
+
+	default:
+		isZero, isBefore := true, false
+		for _, p := range []struct {
+			value int64
+			unit  string
+		}{{year, "year"}, {mon, "mon"}, {mday, "day"}} {
+			if p.value == 0 {
+				continue
+			}
+			if !isZero {
+				b.WriteByte(' ')
+			}
+			if isBefore && p.value > 0 {
+				b.WriteByte('+')
+			}
+			fmt.Fprintf(&b, "%d %s", p.value, p.unit)
+			if p.value != 1 {
+				b.WriteByte('s')
+			}
+			isZero, isBefore = false, p.value < 0
+		}
+		if isZero || iv.Microseconds != 0 {
+			if !isZero {
+				b.WriteByte(' ')
+			}
+			if timeNegative {
+				b.WriteByte('-')
+			} else if isBefore {
+				b.WriteByte('+')
+			}
+			fmt.Fprintf(&b, "%02d:%02d:", abs64(hour), abs64(minute))
+			writeIntervalSeconds(&b, sec, fsec, true)
+		}
+	}
+	return b.String()
+}
+
+// writeIntervalSeconds writes |sec| with the microseconds as a fraction without trailing zeros.
+func writeIntervalSeconds(b *strings.Builder, sec, fsec int64, zeroPad bool) {
+	if zeroPad {
+		fmt.Fprintf(b, "%02d", abs64(sec))
+	} else {
+		fmt.Fprintf(b, "%d", abs64(sec))
+	}
+	if fsec != 0 {
+		b.WriteString(strings.TrimRight(fmt.Sprintf(".%06d", abs64(fsec)), "0"))
+	}
+}
+
+func signChar(negative bool) byte {
+	if negative {
+		return '-'
+	}
+	return '+'
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// intervalUnits maps the unit words PostgreSQL accepts to months (negative values) or
+// microseconds (positive values); days and weeks are handled separately.
+var intervalUnits = map[string]int64{
+	"microsecond": 1, "microseconds": 1, "usec": 1, "usecs": 1, "us": 1,
+	"millisecond": 1000, "milliseconds": 1000, "msec": 1000, "msecs": 1000, "ms": 1000,
+	"second": usecPerSecond, "seconds": usecPerSecond, "sec": usecPerSecond, "secs": usecPerSecond, "s": usecPerSecond,
+	"minute": usecPerMinute, "minutes": usecPerMinute, "min": usecPerMinute, "mins": usecPerMinute, "m": usecPerMinute,
+	"hour": usecPerHour, "hours": usecPerHour, "hr": usecPerHour, "hrs": usecPerHour, "h": usecPerHour,
+	"day": 0, "days": 0, "d": 0,
+	"week": 0, "weeks": 0, "w": 0,
+	"month": -1, "months": -1, "mon": -1, "mons": -1,
+	"year": -12, "years": -12, "yr": -12, "yrs": -12, "y": -12,
+	"decade": -120, "decades": -120,
+	"century": -1200, "centuries": -1200,
+	"millennium": -12000, "millennia": -12000,
+}
+
+// intervalParts accumulates the parts of an interval while parsing.
+type intervalParts struct {
+	months, days, micros int64
+}
+
+// add adds value units, spreading a fraction over the smaller parts as PostgreSQL does: a
+// fraction of a year becomes whole months, a fraction of a month becomes 30-day days and a
+// fraction of a day becomes time.
+func (p *intervalParts) add(value, unit string) error {
+	whole, frac, err := parseIntervalNumber(value)
+	if err != nil {
+		return err
+	}
+	mult, ok := intervalUnits[unit]
+	switch {
+	case !ok:
+		return fmt.Errorf("unknown interval unit %q", unit)
+	case mult > 0:
+		p.micros += whole*mult + int64(math.Round(frac*float64(mult)))
+	case mult == -1:
+		p.months += whole
+		p.addDays(frac * 30)
+	case mult < 0:
+		p.months += whole*-mult + int64(math.Round(frac*float64(-mult)))
+	case unit[0] == 'w':
+		p.days += whole * 7
+		p.addDays(frac * 7)
+	default:
+		p.days += whole
+		p.addDays(frac)
+	}
+	return nil
+}
+
+func (p *intervalParts) addDays(days float64) {
+	whole := math.Trunc(days)
+	p.days += int64(whole)
+	p.micros += int64(math.Round((days - whole) * float64(usecPerDay)))
+}
+
+func (p intervalParts) interval(negate bool) (Interval, error) {
+	if negate {
+		p = intervalParts{-p.months, -p.days, -p.micros}
+	}
+	if p.months != int64(int32(p.months)) || p.days != int64(int32(p.days)) {
+		return Interval{}, fmt.Errorf("interval out of range")
+	}
+	return Interval{Months: int32(p.months), Days: int32(p.days), Microseconds: p.micros}, nil
+}
+
+// parseIntervalNumber splits a decimal such as -1.5 into its signed whole and fractional parts.
+func parseIntervalNumber(s string) (int64, float64, error) {
+	neg := strings.HasPrefix(s, "-")
+	digits := strings.TrimLeft(s, "+-")
+	intPart, fracPart, hasFrac := strings.Cut(digits, ".")
+	if len(s)-len(digits) > 1 || intPart == "" && fracPart == "" || strings.TrimLeft(intPart+fracPart, "0123456789") != "" {
+		return 0, 0, fmt.Errorf("invalid interval number %q", s)
+	}
+	var whole int64
+	if intPart != "" {
+		var err error
+		if whole, err = strconv.ParseInt(intPart, 10, 64); err != nil {
+			return 0, 0, err
+		}
+	}
+	var frac float64
+	if hasFrac && fracPart != "" {
+		frac, _ = strconv.ParseFloat("0."+fracPart, 64)
+	}
+	if neg {
+		return -whole, -frac, nil
+	}
+	return whole, frac, nil
+}
+
+// parseIntervalSeconds reads seconds with up to microsecond precision, rounding further digits.
+func parseIntervalSeconds(s string) (int64, error) {
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" || strings.TrimLeft(intPart+fracPart, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid seconds %q", s)
+	}
+	var sec int64
+	if intPart != "" {
+		var err error
+		if sec, err = strconv.ParseInt(intPart, 10, 64); err != nil {
+			return 0, err
+		}
+	}
+	fracPart = (fracPart + "0000000")[:7]
+	frac, _ := strconv.ParseInt(fracPart, 10, 64)
+	return sec*usecPerSecond + (frac+5)/10, nil
+}
+
+// parseIntervalTime parses [+-]hh:mm[:ss[.ffffff]], or mm:ss.ffffff, into microseconds.
+func parseIntervalTime(s string) (int64, error) {
+	neg := strings.HasPrefix(s, "-")
+	body := strings.TrimLeft(s, "+-")
+	parts := strings.Split(body, ":")
+	if len(s)-len(body) > 1 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid interval time %q", s)
+	}
+	if len(parts) == 2 && strings.Contains(parts[1], ".") {
+		parts = append([]string{"0"}, parts...)
+	}
+	var micros int64
+	for i, p := range parts {
+		if i == 2 {
+			sec, err := parseIntervalSeconds(p)
+			if err != nil {
+				return 0, err
+			}
+			micros += sec
+			continue
+		}
+		n, err := strconv.ParseInt(p, 10, 64)
+		if err != nil || p == "" || p[0] == '+' || p[0] == '-' {
+			return 0, fmt.Errorf("invalid interval time %q", s)
+		}
+		micros += n * []int64{usecPerHour, usecPerMinute}[i]
+	}
+	if neg {
+		micros = -micros
+	}
+	return micros, nil
+}
+
+// isIntervalYearMonth reports whether s is an SQL standard year-month field such as 1-2.
+func isIntervalYearMonth(s string) bool {
+	body := strings.TrimLeft(s, "+-")
+	years, months, ok := strings.Cut(body, "-")
+	return ok && len(s)-len(body) <= 1 && years != "" && months != "" && strings.Trim(years+months, "0123456789") == ""
+}
+
+func isIntervalNumber(s string) bool {
+	_, _, err := parseIntervalNumber(s)
+	return err == nil
+}
+
+// ParseInterval parses an interval in any IntervalStyle output format (see Format), as well as
+// the unit forms PostgreSQL accepts as input, e.g. "1.5 hours", "3 days ago" or "P1W".
+//
+// As in PostgreSQL's sql_standard style, a leading sign on an interval without unit words
+// applies to every part unless another part has a sign of its own: -1-2 is minus 1 year and
+// 2 months.
+func ParseInterval(s string) (Interval, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "P") || strings.HasPrefix(s, "p") {
+		iv, err := parseISO8601Interval(s[1:])
+		if err != nil {
+			return Interval{}, fmt.Errorf("invalid interval %q: %w", orig, err)
+		}
+		return iv, nil
+	}
+	s = strings.TrimPrefix(s, "@")
+	fields := strings.Fields(strings.ToLower(s))
+	ago := len(fields) > 0 && fields[len(fields)-1] == "ago"
+	if ago {
+		fields = fields[:len(fields)-1]
+	}
+	if len(fields) == 0 {
+		return Interval{}, fmt.Errorf("invalid interval %q", orig)
+	}
+
+	leadingSign := false
+	if len(fields[0]) > 1 && fields[0][0] == '-' && fields[0][1] != '-' && fields[0][1] != '+' {
+		leadingSign = true
+		for i, f := range fields {
+			if _, unit := intervalUnits[f]; unit || i > 0 && (f[0] == '+' || f[0] == '-') {
+				leadingSign = false
+				break
+			}
+		}
+		if leadingSign {
+			fields = append([]string{fields[0][1:]}, fields[1:]...)
+		}
+	}
+
+	var p intervalParts
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		var err error
+		switch {
+		case strings.Contains(f, ":"):
+			var micros int64
+			micros, err = parseIntervalTime(f)
+			p.micros += micros
+		case isIntervalYearMonth(f):
+			neg := strings.HasPrefix(f, "-")
+			years, months, _ := strings.Cut(strings.TrimLeft(f, "+-"), "-")
+			y, _ := strconv.ParseInt(years, 10, 64)
+			m, _ := strconv.ParseInt(months, 10, 64)
+			if neg {
+				y, m = -y, -m
+			}
+			p.months += y*12 + m
+		case isIntervalNumber(f):
+			next := ""
+			if i+1 < len(fields) {
+				next = fields[i+1]
+			}
+			_, isUnit := intervalUnits[next]
+			switch {
+			case isUnit:
+				err = p.add(f, next)
+				i++
+			case strings.Contains(next, ":"):
+				err = p.add(f, "day") // SQL standard day-time: 3 4:05:06
+			case next == "":
+				err = p.add(f, "second")
+			default:
+				err = fmt.Errorf("unexpected %q", next)
+			}
+		default:
+			err = fmt.Errorf("unexpected %q", f)
+		}
+		if err != nil {
+			return Interval{}, fmt.Errorf("invalid interval %q: %w", orig, err)
+		}
+	}
+	iv, err := p.interval(leadingSign != ago)
+	if err != nil {
+		return Interval{}, fmt.Errorf("invalid interval %q: %w", orig, err)
+	}
+	return iv, nil
+}
+
+var (
+	iso8601DateUnits = map[string]string{"Y": "year", "M": "month", "W": "week", "D": "day"}
+	iso8601TimeUnits = map[string]string{"H": "hour", "M": "minute", "S": "second"}
+)
+
+// parseISO8601Interval parses the part after P of an ISO 8601 duration with designators,
+// e.g. 1Y2M3DT4H5M6.5S. Any number may carry a sign and a fraction.
+func parseISO8601Interval(s string) (Interval, error) {
+	var p intervalParts
+	inTime := false
+	if s == "" {
+		return Interval{}, fmt.Errorf("empty duration")
+	}
+	for s != "" {
+		if s[0] == 'T' || s[0] == 't' {
+			if inTime {
+				return Interval{}, fmt.Errorf("repeated T")
+			}
+			inTime, s = true, s[1:]
+			continue
+		}
+		end := strings.IndexFunc(s, func(r rune) bool { return r != '+' && r != '-' && r != '.' && (r < '0' || r > '9') })
+		if end <= 0 {
+			return Interval{}, fmt.Errorf("expected a number in %q", s)
+		}
+		number, designator := s[:end], strings.ToUpper(s[end:end+1])
+		s = s[end+1:]
+		unit := iso8601DateUnits[designator]
+		if inTime {
+			unit = iso8601TimeUnits[designator]
+		}
+		if unit == "" {
+			return Interval{}, fmt.Errorf("unexpected designator %q", designator)
+		}
+		if err := p.add(number, unit); err != nil {
+			return Interval{}, err
+		}
+	}
+	return p.interval(false)
+}
+
+func (iv *Interval) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*iv = Interval{}
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("cannot scan type %T into Interval", src)
+	}
+	parsed, err := ParseInterval(s)
+	if err != nil {
+		return err
+	}
+	*iv = parsed
+	return nil
+}
+
+// Value writes the interval in ISO 8601 format, which PostgreSQL reads the same way under every
+// IntervalStyle.
+func (iv Interval) Value() (driver.Value, error) {
+	return iv.Format(IntervalStyleISO8601), nil
+}
+
+// MarshalJSON writes the interval as an ISO 8601 duration string, e.g. "P1M2DT3H".
+func (iv Interval) MarshalJSON() ([]byte, error) {
+	return json.Marshal(iv.Format(IntervalStyleISO8601))
+}
+
+// UnmarshalJSON accepts a string in any format ParseInterval reads.
+func (iv *Interval) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return iv.UnmarshalText([]byte(s))
+}
+
+func (iv Interval) MarshalText() ([]byte, error) {
+	return []byte(iv.Format(IntervalStyleISO8601)), nil
+}
+
+func (iv *Interval) UnmarshalText(data []byte) error {
+	parsed, err := ParseInterval(string(data))
+	if err != nil {
+		return err
+	}
+	*iv = parsed
+	return nil
+}
+
+func (Interval) GormDataType() string {
+	return "interval"
+}
+
+func (Interval) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "interval"
+	}
+	return ""
+}
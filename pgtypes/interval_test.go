@@ -0,0 +1,137 @@
+package pgtypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestInterval_Format(t *testing.T) {
+	hms := 4*usecPerHour + 5*usecPerMinute + 6*usecPerSecond
+	for _, c := range []struct {
+		iv                                  Interval
+		postgres, verbose, sqlStandard, iso string
+	}{
+		{Interval{Months: 14}, "1 year 2 mons", "@ 1 year 2 mons", "1-2", "P1Y2M"},
+		{Interval{Days: 3, Microseconds: hms}, "3 days 04:05:06", "@ 3 days 4 hours 5 mins 6 secs", "3 4:05:06", "P3DT4H5M6S"},
+		{Interval{Months: -14, Days: 3, Microseconds: -hms}, "-1 years -2 mons +3 days -04:05:06", "@ 1 year 2 mons -3 days 4 hours 5 mins 6 secs ago", "-1-2 +3 -4:05:06", "P-1Y-2M3DT-4H-5M-6S"},
+		{Interval{}, "00:00:00", "@ 0", "0", "PT0S"},
+		{Interval{Microseconds: -usecPerHour}, "-01:00:00", "@ 1 hour ago", "-1:00:00", "PT-1H"},
+		{Interval{Days: -3, Microseconds: -hms}, "-3 days -04:05:06", "@ 3 days 4 hours 5 mins 6 secs ago", "-3 4:05:06", "P-3DT-4H-5M-6S"},
+		{Interval{Microseconds: 1500000}, "00:00:01.5", "@ 1.5 secs", "0:00:01.5", "PT1.5S"},
+		{Interval{Months: 1, Microseconds: 1}, "1 mon 00:00:00.000001", "@ 1 mon 0.000001 secs", "+0-1 +0 +0:00:00.000001", "P1MT0.000001S"},
+	} {
+		for style, want := range map[IntervalStyle]string{
+			IntervalStylePostgres:        c.postgres,
+			IntervalStylePostgresVerbose: c.verbose,
+			IntervalStyleSQLStandard:     c.sqlStandard,
+			IntervalStyleISO8601:         c.iso,
+		} {
+			if got := c.iv.Format(style); got != want {
+				t.Fatalf("%+v in %s: got %q, want %q", c.iv, style, got, want)
+			}
+			if back, err := ParseInterval(want); err != nil || back != c.iv {
+				t.Fatalf("parse %q (%s): got %+v (%v), want %+v", want, style, back, err, c.iv)
+			}
+		}
+	}
+}
+
+func TestInterval_RoundTrip(t *testing.T) {
+	values := []int64{0, 1, -1, 11, -13, 59, 1000001, -86399999999, 123456789012}
+	for _, months := range values {
+		for _, days := range values {
+			for _, micros := range values {
+				iv := Interval{Months: int32(months), Days: int32(days % 100000), Microseconds: micros}
+				for _, style := range []IntervalStyle{IntervalStylePostgres, IntervalStylePostgresVerbose, IntervalStyleSQLStandard, IntervalStyleISO8601} {
+					s := iv.Format(style)
+					if back, err := ParseInterval(s); err != nil || back != iv {
+						t.Fatalf("%+v in %s as %q: got %+v (%v)", iv, style, s, back, err)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestParseInterval_Input(t *testing.T) {
+	for _, c := range []struct {
+		in   string
+		want Interval
+	}{
+		{"1 day", Interval{Days: 1}},
+		{"1 year 2 mons", Interval{Months: 14}},
+		{"1.5 hours", Interval{Microseconds: 90 * usecPerMinute}},
+		{"1.5 years", Interval{Months: 18}},
+		{"1.5 mons", Interval{Months: 1, Days: 15}},
+		{"1.5 days", Interval{Days: 1, Microseconds: 12 * usecPerHour}},
+		{"2 weeks", Interval{Days: 14}},
+		{"3 days ago", Interval{Days: -3}},
+		{"-1 days +02:00:00", Interval{Days: -1, Microseconds: 2 * usecPerHour}},
+		{"10", Interval{Microseconds: 10 * usecPerSecond}},
+		{"00:00:00.0000005", Interval{Microseconds: 1}},
+		{"05:30.5", Interval{Microseconds: 5*usecPerMinute + 30500000}},
+		{"P1W", Interval{Days: 7}},
+		{"P1DT0.5S", Interval{Days: 1, Microseconds: 500000}},
+	} {
+		got, err := ParseInterval(c.in)
+		if err != nil || got != c.want {
+			t.Fatalf("ParseInterval(%q) = %+v (%v), want %+v", c.in, got, err, c.want)
+		}
+	}
+	for _, bad := range []string{"", "abc", "1 fortnight", "P", "P1X", "1:2:3:4", "--1-2", "1 2"} {
+		if _, err := ParseInterval(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestInterval_ScanValueJSON(t *testing.T) {
+	var iv Interval
+	if err := iv.Scan([]byte("1 year 2 mons 3 days 04:05:06")); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if v, err := iv.Value(); err != nil || v != "P1Y2M3DT4H5M6S" {
+		t.Fatalf("unexpected value: %v (%v)", v, err)
+	}
+	b, _ := json.Marshal(iv)
+	var out Interval
+	if err := json.Unmarshal(b, &out); err != nil || out != iv {
+		t.Fatalf("json roundtrip: %s -> %+v (%v)", b, out, err)
+	}
+	start := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+	if got := (Interval{Months: 1, Days: 1}).AddTo(start); !got.Equal(time.Date(2024, 3, 3, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected AddTo result: %v", got)
+	}
+	var a IntervalArray
+	if err := a.Scan(`{"1 day","-01:00:00",NULL}`); err == nil {
+		t.Fatal("expected error for NULL element")
+	}
+	if err := a.Scan(`{"1 day","-01:00:00"}`); err != nil || len(a) != 2 || a[1].Microseconds != -usecPerHour {
+		t.Fatalf("unexpected array: %v (%v)", a, err)
+	}
+	if v, err := a.Value(); err != nil || v != "{P1D,PT-1H}" {
+		t.Fatalf("unexpected array value: %v (%v)", v, err)
+	}
+}
+
+func TestDuration_IntervalFormats(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"1 day":                24 * time.Hour,
+		"-01:00:00":            -time.Hour,
+		"1 year 2 mons":        420 * 24 * time.Hour,
+		"00:00:01.25":          1250 * time.Millisecond,
+		"3 days 04:05:06":      76*time.Hour + 5*time.Minute + 6*time.Second,
+		"1h2m3s":               time.Hour + 2*time.Minute + 3*time.Second,
+		"P1DT2H":               26 * time.Hour,
+		"@ 1 hour 30 mins ago": -90 * time.Minute,
+	} {
+		var d Duration
+		if err := d.Scan(in); err != nil || d.Duration != want {
+			t.Fatalf("scan %q: got %v (%v), want %v", in, d.Duration, err, want)
+		}
+	}
+	if v, err := FromDuration(time.Hour + 1500*time.Millisecond).Value(); err != nil || v != "PT1H1.5S" {
+		t.Fatalf("unexpected value: %v (%v)", v, err)
+	}
+}
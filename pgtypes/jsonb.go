@@ -0,0 +1,74 @@
+package pgtypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// JSONB is a json or jsonb value decoded into a T, such as a struct describing the document.
+// NULL scans as the zero T; use a *JSONB[T] to tell NULL apart from a JSON null.
+type JSONB[T any] struct {
+	Data T
+}
+
+// NewJSONB returns the JSONB holding data.
+func NewJSONB[T any](data T) JSONB[T] {
+	return JSONB[T]{Data: data}
+}
+
+func (j *JSONB[T]) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*j = JSONB[T]{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into JSONB[%T]", src, j.Data)
+	}
+	var out JSONB[T]
+	if err := json.Unmarshal(data, &out.Data); err != nil {
+		return fmt.Errorf("cannot scan into JSONB[%T]: %w", j.Data, err)
+	}
+	*j = out
+	return nil
+}
+
+func (j JSONB[T]) Value() (driver.Value, error) {
+	b, err := json.Marshal(j.Data)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (j JSONB[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Data)
+}
+
+func (j *JSONB[T]) UnmarshalJSON(data []byte) error {
+	var out JSONB[T]
+	if err := json.Unmarshal(data, &out.Data); err != nil {
+		return err
+	}
+	*j = out
+	return nil
+}
+
+func (JSONB[T]) GormDataType() string {
+	return "jsonb"
+}
+
+func (JSONB[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "jsonb"
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+package pgtypes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONB(t *testing.T) {
+	type shipping struct {
+		City string   `json:"city"`
+		Tags []string `json:"tags,omitempty"`
+	}
+	var j JSONB[shipping]
+	if err := j.Scan(`{"city":"Oslo","tags":["a"]}`); err != nil || j.Data.City != "Oslo" || len(j.Data.Tags) != 1 {
+		t.Fatalf("scan: %+v (%v)", j, err)
+	}
+	if err := j.Scan([]byte(`{"city":"Bergen"}`)); err != nil || j.Data.Tags != nil {
+		t.Fatalf("scan must reset the previous value: %+v (%v)", j, err)
+	}
+	if v, _ := j.Value(); v != `{"city":"Bergen"}` {
+		t.Fatalf("value: %v", v)
+	}
+	if err := j.Scan(`{"city":1}`); err == nil || j.Data.City != "Bergen" {
+		t.Fatalf("expected an error keeping the value, got %v (%+v)", err, j)
+	}
+	if err := j.Scan(nil); err != nil || j.Data.City != "" {
+		t.Fatalf("NULL: %+v (%v)", j, err)
+	}
+	b, _ := json.Marshal(struct{ S JSONB[shipping] }{NewJSONB(shipping{City: "Oslo"})})
+	if string(b) != `{"S":{"city":"Oslo"}}` {
+		t.Fatalf("json: %s", b)
+	}
+	var items JSONB[[]int]
+	if err := json.Unmarshal([]byte(`[1,2]`), &items); err != nil || len(items.Data) != 2 || items.GormDataType() != "jsonb" {
+		t.Fatalf("unmarshal: %+v (%v)", items, err)
+	}
+}
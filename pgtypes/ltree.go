@@ -0,0 +1,112 @@
+package pgtypes
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// LTree is a value of the ltree extension type: a path of labels separated by dots, such as
+// Top.Science.Astronomy. The empty LTree is the path with no labels.
+type LTree string
+
+// LTreeArray is a PostgreSQL ltree[].
+type LTreeArray = Array[LTree]
+
+// ParseLTree validates a label path. Labels are non-empty runs of letters, digits, underscores
+// and hyphens of at most 1000 characters.
+func ParseLTree(s string) (LTree, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", nil
+	}
+	for _, label := range strings.Split(s, ".") {
+		if label == "" || len(label) > 1000 {
+			return "", fmt.Errorf("invalid ltree %q: labels must be 1 to 1000 characters", s)
+		}
+		for _, r := range label {
+			if r != '_' && r != '-' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				return "", fmt.Errorf("invalid ltree %q: unexpected %q in label %q", s, r, label)
+			}
+		}
+	}
+	return LTree(s), nil
+}
+
+// Labels returns the labels of the path, nil for the empty path.
+func (l LTree) Labels() []string {
+	if l == "" {
+		return nil
+	}
+	return strings.Split(string(l), ".")
+}
+
+// Level returns the number of labels, as PostgreSQL's nlevel.
+func (l LTree) Level() int {
+	if l == "" {
+		return 0
+	}
+	return strings.Count(string(l), ".") + 1
+}
+
+// Parent returns the path without its last label; the parent of a single label is empty.
+func (l LTree) Parent() LTree {
+	if i := strings.LastIndexByte(string(l), '.'); i >= 0 {
+		return l[:i]
+	}
+	return ""
+}
+
+// Child returns the path extended with label.
+func (l LTree) Child(label string) LTree {
+	if l == "" {
+		return LTree(label)
+	}
+	return l + "." + LTree(label)
+}
+
+// IsAncestorOf reports whether l is an ancestor of other or equal to it, as PostgreSQL's
+// l @> other. The empty path is an ancestor of every path.
+func (l LTree) IsAncestorOf(other LTree) bool {
+	return l == "" || other == l || strings.HasPrefix(string(other), string(l)+".")
+}
+
+// IsDescendantOf reports whether l is a descendant of other or equal to it, as PostgreSQL's
+// l <@ other.
+func (l LTree) IsDescendantOf(other LTree) bool {
+	return other.IsAncestorOf(l)
+}
+
+func (l *LTree) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*l = ""
+	case []byte:
+		*l, err = ParseLTree(string(v))
+	case string:
+		*l, err = ParseLTree(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into LTree", src)
+	}
+	return err
+}
+
+func (l LTree) Value() (driver.Value, error) {
+	return string(l), nil
+}
+
+func (LTree) GormDataType() string {
+	return "ltree"
+}
+
+func (LTree) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "ltree"
+	}
+	return ""
+}
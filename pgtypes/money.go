@@ -0,0 +1,220 @@
+package pgtypes
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Money is a PostgreSQL money value in cents, hundredths of the currency unit: Money(1234) is
+// 12.34. PostgreSQL writes money in the format of its lc_monetary setting ($1,234.56,
+// 1.234,56 €, ...), which Scan reads for any locale.
+type Money int64
+
+// MoneyArray is a PostgreSQL money[].
+type MoneyArray = Array[Money]
+
+// ParseMoney parses an amount in the output format of any lc_monetary locale, or a plain
+// number such as -1234.5. The number may be surrounded by currency symbols ($, €), currency
+// names separated from it by a space or a symbol (CHF 1'234.05, 12,50 kr) and spaces; anything
+// else, such as a letter next to a digit, is an error. A minus sign before or after the number,
+// or parentheses around it, make the amount negative. Digits may be grouped with '.', ',',
+// apostrophes or spaces. The last '.' or ',' is the decimal separator unless exactly three
+// digits follow it and it is the only kind of separator, which makes it a thousands separator:
+// $1,234 is 1234.00 but 1,23 € is 1.23. Amounts with more than two decimals are rounded to the
+// cent.
+func ParseMoney(s string) (Money, error) {
+	invalid := fmt.Errorf("invalid money value %q", s)
+	runes := []rune(strings.TrimSpace(s))
+	isDigit := func(i int) bool {
+		return i >= 0 && i < len(runes) && runes[i] >= '0' && runes[i] <= '9'
+	}
+	var digits strings.Builder
+	sep, seps := -1, ""
+	minus, open, closed := 0, false, false
+	state := 0 // 0 before the number, 1 in it, 2 after it
+	for i, r := range runes {
+		// A separator inside the number is followed by a digit; only '.' and ',' may start it.
+		inner := (r == '.' || r == ',' || r == '\'' || unicode.IsSpace(r)) && isDigit(i+1) &&
+			(state == 1 || r == '.' || r == ',')
+		if state == 1 && !isDigit(i) && !inner {
+			state = 2
+		}
+		switch {
+		case isDigit(i):
+			if state == 2 || i > 0 && unicode.IsLetter(runes[i-1]) {
+				return 0, invalid
+			}
+			state = 1
+			digits.WriteRune(r)
+		case inner:
+			state = 1
+			if r == '.' || r == ',' {
+				sep = digits.Len()
+				if !strings.ContainsRune(seps, r) {
+					seps += string(r)
+				}
+			}
+		case r == '-':
+			if minus++; minus > 1 {
+				return 0, invalid
+			}
+		case r == '(':
+			if state != 0 || open {
+				return 0, invalid
+			}
+			open = true
+		case r == ')':
+			if state != 2 || !open || closed {
+				return 0, invalid
+			}
+			closed = true
+		case unicode.IsLetter(r):
+			if isDigit(i - 1) {
+				return 0, invalid
+			}
+		case unicode.IsSpace(r), unicode.Is(unicode.Sc, r):
+		default:
+			return 0, invalid
+		}
+	}
+	d := digits.String()
+	if d == "" || open != closed {
+		return 0, invalid
+	}
+	whole, frac := d, ""
+	if sep >= 0 && (len(d)-sep != 3 || len(seps) == 2) {
+		whole, frac = d[:sep], d[sep:]
+	}
+	return moneyFromParts(s, whole, frac, minus > 0 || open)
+}
+
+// moneyFromParts returns the amount whole.frac, rounded to the cent.
+func moneyFromParts(s, whole, frac string, negative bool) (Money, error) {
+	if whole == "" {
+		whole = "0"
+	}
+	units, err := strconv.ParseUint(whole, 10, 63)
+	if err != nil || units > math.MaxInt64/100-1 {
+		return 0, fmt.Errorf("invalid money value %q: out of range", s)
+	}
+	cents := int64(units) * 100
+	if len(frac) > 2 {
+		f, _ := strconv.ParseFloat("0."+frac, 64)
+		cents += int64(math.Round(f * 100))
+	} else if frac != "" {
+		c, _ := strconv.Atoi((frac + "0")[:2])
+		cents += int64(c)
+	}
+	if negative {
+		cents = -cents
+	}
+	return Money(cents), nil
+}
+
+// MoneyFromFloat returns the amount rounded to the cent.
+func MoneyFromFloat(f float64) Money {
+	return Money(math.Round(f * 100))
+}
+
+// Units returns the whole currency units, truncated toward zero.
+func (m Money) Units() int64 {
+	return int64(m) / 100
+}
+
+// Float64 returns the amount in currency units.
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+// String returns the amount as a plain number with two decimals, such as -1234.50.
+func (m Money) String() string {
+	sign, v := "", int64(m)
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, v/100, v%100)
+}
+
+func (m *Money) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+	case []byte:
+		*m, err = ParseMoney(string(v))
+	case string:
+		*m, err = ParseMoney(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into Money", src)
+	}
+	return err
+}
+
+// Value returns the amount as a plain number, which PostgreSQL reads as money when lc_monetary
+// uses '.' as the decimal separator. GORM inserts and updates go through GormValue instead,
+// which works for every locale; a MoneyArray does the same through numeric[].
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// GormValue casts the amount through numeric, whose input does not depend on lc_monetary.
+func (m Money) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if db.Dialector.Name() != "postgres" {
+		return clause.Expr{SQL: "?", Vars: []interface{}{m.String()}}
+	}
+	return clause.Expr{SQL: "?::numeric::money", Vars: []interface{}{m.String()}}
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON reads a JSON number, or a string in any format ParseMoney accepts.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	var v Money
+	var err error
+	if unquoted, uerr := strconv.Unquote(s); uerr == nil {
+		v, err = ParseMoney(unquoted)
+	} else {
+		v, err = parseMoneyNumber(s)
+	}
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// parseMoneyNumber parses a JSON number, whose '.' is always the decimal separator.
+func parseMoneyNumber(s string) (Money, error) {
+	if _, err := strconv.ParseFloat(s, 64); err != nil || strings.ContainsAny(s, "eE") {
+		return 0, fmt.Errorf("invalid money value %s", s)
+	}
+	negative := strings.HasPrefix(s, "-")
+	whole, frac, _ := strings.Cut(strings.TrimPrefix(s, "-"), ".")
+	return moneyFromParts(s, whole, frac, negative)
+}
+
+func (Money) GormDataType() string {
+	return "money"
+}
+
+func (Money) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "money"
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package pgtypes
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestMoney(t *testing.T) {
+	for text, want := range map[string]Money{
+		"$1,234.56":    123456,
+		"-$1,234.56":   -123456,
+		"($0.50)":      -50,
+		"1.234,56 €":   123456,
+		"-1 234,5 €":   -123450,
+		"$1,234":       123400,
+		"1,23 €":       123,
+		"¥1,235":       123500,
+		"CHF 1'234.05": 123405,
+		"12.345":       1234500,
+		"1.234,567 kr": 123457,
+		"0.005":        500, // a lone separator before three digits groups thousands
+	} {
+		var m Money
+		if err := m.Scan([]byte(text)); err != nil || m != want {
+			t.Errorf("%s: got %d (%v), want %d", text, m, err, want)
+		}
+	}
+	if _, err := ParseMoney("$"); err == nil {
+		t.Error("expected an error without digits")
+	}
+	for _, text := range []string{"abc5", "5abc", "x1-2", "1-2", "--5", "(5", "5)", "1.2.3x", "#5"} {
+		if m, err := ParseMoney(text); err == nil {
+			t.Errorf("%s: expected an error, got %d", text, m)
+		}
+	}
+	if m := Money(-123405); m.String() != "-1234.05" || m.Units() != -1234 || m.Float64() != -1234.05 {
+		t.Errorf("helpers: %s %d %v", m, m.Units(), m.Float64())
+	}
+	if v, _ := Money(5).Value(); v != "0.05" {
+		t.Errorf("value: %v", v)
+	}
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := Money(150).GormValue(context.Background(), db); e.SQL != "?" || e.Vars[0] != "1.50" {
+		t.Errorf("GormValue: %+v", e)
+	}
+
+	var s struct{ A, B, C Money }
+	if err := json.Unmarshal([]byte(`{"A":12.345,"B":"$1,234.50","C":-3}`), &s); err != nil || s.A != 1235 || s.B != 123450 || s.C != -300 {
+		t.Fatalf("json: %+v (%v)", s, err)
+	}
+	if b, _ := json.Marshal(s); string(b) != `{"A":12.35,"B":1234.50,"C":-3.00}` {
+		t.Fatalf("json: %s", b)
+	}
+	if err := json.Unmarshal([]byte(`{"A":"x1-2"}`), &s); err == nil {
+		t.Fatal("json: expected an error for x1-2")
+	}
+	var a MoneyArray
+	if err := a.Scan(`{"$1.00","$2,000.00"}`); err != nil || a[1] != 200000 {
+		t.Fatalf("money[]: %v (%v)", a, err)
+	}
+	if e := a.GormValue(context.Background(), db); e.SQL != "?" || e.Vars[0] != "{1.00,2000.00}" {
+		t.Errorf("money[] GormValue: %+v", e)
+	}
+	pg, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := a.GormValue(context.Background(), pg); e.SQL != "?::numeric[]::money[]" {
+		t.Errorf("money[] GormValue on postgres: %+v", e)
+	}
+}
@@ -0,0 +1,344 @@
+package pgtypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Array2D is a two-dimensional PostgreSQL array (integer[][], text[][], ...) of T, scanned row by
+// row into nested slices. Elements are converted like Array[T]. PostgreSQL arrays are
+// rectangular: every row must have the same length, which Scan checks and Value requires.
+type Array2D[T any] [][]T
+
+// Array3D is a three-dimensional PostgreSQL array of T; see Array2D.
+type Array3D[T any] [][][]T
+
+// ArrayND is a PostgreSQL array of any number of dimensions, kept flat: Elems holds the elements
+// in row-major order and Dims the dimensions, outermost first, with their lower bounds.
+type ArrayND[T any] struct {
+	Dims  []ArrayDimension
+	Elems []T
+}
+
+// scanArrayND parses the array literal in src for the array type named target. ndims, when
+// not 0, is the number of dimensions a non-empty array must have.
+func scanArrayND[T any](src interface{}, target string, ndims int) ([]T, []ArrayDimension, error) {
+	var input string
+	switch t := src.(type) {
+	case []byte:
+		input = string(t)
+	case string:
+		input = t
+	default:
+		return nil, nil, fmt.Errorf("cannot scan type %T into %s", src, target)
+	}
+	codec := arrayCodecFor[T]()
+	elems, dims, err := parseArrayLiteral(input, codec.delimiter())
+	if err != nil {
+		return nil, nil, err
+	}
+	if ndims > 0 && len(dims) > 0 && len(dims) != ndims {
+		return nil, nil, fmt.Errorf("cannot scan a %d-dimensional array into %s", len(dims), target)
+	}
+	out := make([]T, len(elems))
+	for i, e := range elems {
+		if e == nil {
+			return nil, nil, fmt.Errorf("cannot scan NULL element into %s", target)
+		}
+		if out[i], err = codec.Parse(*e); err != nil {
+			return nil, nil, err
+		}
+	}
+	return out, dims, nil
+}
+
+func formatArrayND[T any](elems []T, dims []ArrayDimension) (string, error) {
+	codec := arrayCodecFor[T]()
+	strs := make([]*string, len(elems))
+	for i, v := range elems {
+		s, err := codec.Format(v)
+		if err != nil {
+			return "", err
+		}
+		strs[i] = &s
+	}
+	return formatArrayLiteral(strs, dims, codec.Quote, codec.delimiter())
+}
+
+func (a *Array2D[T]) Scan(src interface{}) error {
+	if src == nil {
+		*a = nil
+		return nil
+	}
+	elems, dims, err := scanArrayND[T](src, fmt.Sprintf("%T", *a), 2)
+	if err != nil {
+		return err
+	}
+	out := Array2D[T]{}
+	if len(dims) > 0 {
+		out = make(Array2D[T], dims[0].Length)
+		for i := range out {
+			out[i] = elems[i*dims[1].Length : (i+1)*dims[1].Length : (i+1)*dims[1].Length]
+		}
+	}
+	*a = out
+	return nil
+}
+
+func (a Array2D[T]) Value() (driver.Value, error) {
+	rows, cols, err := a.dims()
+	if err != nil {
+		return nil, err
+	}
+	if rows*cols == 0 {
+		return "{}", nil
+	}
+	elems := make([]T, 0, rows*cols)
+	for _, row := range a {
+		elems = append(elems, row...)
+	}
+	return formatArrayND(elems, []ArrayDimension{{rows, 1}, {cols, 1}})
+}
+
+// Dims returns the number of rows and columns. It fails when the rows differ in length.
+func (a Array2D[T]) Dims() (rows, cols int, err error) {
+	return a.dims()
+}
+
+func (a Array2D[T]) dims() (rows, cols int, err error) {
+	if len(a) == 0 {
+		return 0, 0, nil
+	}
+	cols = len(a[0])
+	for i, row := range a {
+		if len(row) != cols {
+			return 0, 0, fmt.Errorf("%T is not rectangular: row %d has %d elements, row 0 has %d", a, i, len(row), cols)
+		}
+	}
+	return len(a), cols, nil
+}
+
+func (Array2D[T]) GormDataType() string {
+	return Array[T]{}.GormDataType() + "[]"
+}
+
+func (Array2D[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if dbType := (Array[T]{}).GormDBDataType(db, field); dbType != "" {
+		return dbType + "[]"
+	}
+	return ""
+}
+
+func (a *Array3D[T]) Scan(src interface{}) error {
+	if src == nil {
+		*a = nil
+		return nil
+	}
+	elems, dims, err := scanArrayND[T](src, fmt.Sprintf("%T", *a), 3)
+	if err != nil {
+		return err
+	}
+	out := Array3D[T]{}
+	if len(dims) > 0 {
+		rows, cols := dims[1].Length, dims[2].Length
+		out = make(Array3D[T], dims[0].Length)
+		for i := range out {
+			out[i] = make([][]T, rows)
+			for j := range out[i] {
+				start := (i*rows + j) * cols
+				out[i][j] = elems[start : start+cols : start+cols]
+			}
+		}
+	}
+	*a = out
+	return nil
+}
+
+func (a Array3D[T]) Value() (driver.Value, error) {
+	dims, err := a.dims()
+	if err != nil {
+		return nil, err
+	}
+	if dims[0]*dims[1]*dims[2] == 0 {
+		return "{}", nil
+	}
+	elems := make([]T, 0, dims[0]*dims[1]*dims[2])
+	for _, plane := range a {
+		for _, row := range plane {
+			elems = append(elems, row...)
+		}
+	}
+	return formatArrayND(elems, []ArrayDimension{{dims[0], 1}, {dims[1], 1}, {dims[2], 1}})
+}
+
+// Dims returns the length of each dimension. It fails when the array is not rectangular.
+func (a Array3D[T]) Dims() ([3]int, error) {
+	return a.dims()
+}
+
+func (a Array3D[T]) dims() ([3]int, error) {
+	if len(a) == 0 {
+		return [3]int{}, nil
+	}
+	rows, cols := len(a[0]), 0
+	if rows > 0 {
+		cols = len(a[0][0])
+	}
+	for i, plane := range a {
+		if len(plane) != rows {
+			return [3]int{}, fmt.Errorf("%T is not rectangular: [%d] has %d rows, [0] has %d", a, i, len(plane), rows)
+		}
+		for j, row := range plane {
+			if len(row) != cols {
+				return [3]int{}, fmt.Errorf("%T is not rectangular: [%d][%d] has %d elements, [0][0] has %d", a, i, j, len(row), cols)
+			}
+		}
+	}
+	return [3]int{len(a), rows, cols}, nil
+}
+
+func (Array3D[T]) GormDataType() string {
+	return Array[T]{}.GormDataType() + "[][]"
+}
+
+func (Array3D[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if dbType := (Array[T]{}).GormDBDataType(db, field); dbType != "" {
+		return dbType + "[][]"
+	}
+	return ""
+}
+
+// NewArrayND returns an array with the given dimension lengths (lower bounds 1) and elements in
+// row-major order. It fails when the lengths do not multiply to len(elems).
+func NewArrayND[T any](lengths []int, elems []T) (ArrayND[T], error) {
+	dims := make([]ArrayDimension, len(lengths))
+	for i, n := range lengths {
+		dims[i] = ArrayDimension{Length: n, LowerBound: 1}
+	}
+	a := ArrayND[T]{Dims: dims, Elems: elems}
+	return a, a.check()
+}
+
+func (a ArrayND[T]) check() error {
+	n := 0
+	if len(a.Dims) > 0 {
+		n = 1
+	}
+	for _, d := range a.Dims {
+		n *= d.Length
+	}
+	if n != len(a.Elems) || len(a.Dims) > 0 && n == 0 {
+		return fmt.Errorf("array dimensions %v do not match %d elements", a.Dims, len(a.Elems))
+	}
+	return nil
+}
+
+// At returns the element at the given zero-based index in each dimension.
+func (a ArrayND[T]) At(index ...int) T {
+	if len(index) != len(a.Dims) {
+		panic(fmt.Sprintf("pgtypes: %d indexes for a %d-dimensional array", len(index), len(a.Dims)))
+	}
+	pos := 0
+	for i, d := range a.Dims {
+		if index[i] < 0 || index[i] >= d.Length {
+			panic(fmt.Sprintf("pgtypes: index %d out of range for dimension %d of length %d", index[i], i, d.Length))
+		}
+		pos = pos*d.Length + index[i]
+	}
+	return a.Elems[pos]
+}
+
+func (a *ArrayND[T]) Scan(src interface{}) error {
+	if src == nil {
+		*a = ArrayND[T]{}
+		return nil
+	}
+	elems, dims, err := scanArrayND[T](src, fmt.Sprintf("%T", *a), 0)
+	if err != nil {
+		return err
+	}
+	*a = ArrayND[T]{Dims: dims, Elems: elems}
+	return nil
+}
+
+func (a ArrayND[T]) Value() (driver.Value, error) {
+	if err := a.check(); err != nil {
+		return nil, err
+	}
+	return formatArrayND(a.Elems, a.Dims)
+}
+
+// MarshalJSON writes the array as nested JSON arrays.
+func (a ArrayND[T]) MarshalJSON() ([]byte, error) {
+	if err := a.check(); err != nil {
+		return nil, err
+	}
+	if len(a.Dims) == 0 {
+		return []byte("[]"), nil
+	}
+	var nest func(elems []T, dims []ArrayDimension) []any
+	nest = func(elems []T, dims []ArrayDimension) []any {
+		out := make([]any, dims[0].Length)
+		step := len(elems) / dims[0].Length
+		for i := range out {
+			if len(dims) == 1 {
+				out[i] = elems[i]
+			} else {
+				out[i] = nest(elems[i*step:(i+1)*step], dims[1:])
+			}
+		}
+		return out
+	}
+	return json.Marshal(nest(a.Elems, a.Dims))
+}
+
+// UnmarshalJSON reads nested JSON arrays, which must be rectangular.
+func (a *ArrayND[T]) UnmarshalJSON(data []byte) error {
+	out := ArrayND[T]{}
+	var walk func(data []byte, depth int) error
+	walk = func(data []byte, depth int) error {
+		// The first path down to an element fixes the number of dimensions.
+		if depth == len(out.Dims) && (len(out.Elems) > 0 || !strings.HasPrefix(strings.TrimSpace(string(data)), "[")) {
+			var v T
+			if err := json.Unmarshal(data, &v); err != nil {
+				return err
+			}
+			out.Elems = append(out.Elems, v)
+			return nil
+		}
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil || depth < len(out.Dims) && len(items) != out.Dims[depth].Length {
+			return fmt.Errorf("cannot unmarshal %s into %T: arrays are not rectangular", data, out)
+		}
+		if depth == len(out.Dims) {
+			out.Dims = append(out.Dims, ArrayDimension{Length: len(items), LowerBound: 1})
+		}
+		for _, item := range items {
+			if err := walk(item, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(data, 0); err != nil {
+		return err
+	}
+	if len(out.Elems) == 0 {
+		out = ArrayND[T]{}
+	}
+	*a = out
+	return nil
+}
+
+func (a ArrayND[T]) GormDataType() string {
+	return Array[T]{}.GormDataType()
+}
+
+func (ArrayND[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return Array[T]{}.GormDBDataType(db, field)
+}
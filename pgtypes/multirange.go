@@ -0,0 +1,164 @@
+package pgtypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Multirange is a PostgreSQL multirange value such as {[1,3),[5,7)}. A nil Multirange is NULL;
+// an empty, non-nil one is {}.
+type Multirange[T any] []Range[T]
+
+type (
+	Int4Multirange = Multirange[int32]
+	Int8Multirange = Multirange[int64]
+	NumMultirange  = Multirange[Decimal]
+	TsMultirange   = Multirange[time.Time]
+	TstzMultirange = Multirange[time.Time]
+	DateMultirange = Multirange[time.Time]
+)
+
+func (m *Multirange[T]) Scan(src interface{}) error {
+	if src == nil {
+		*m = nil
+		return nil
+	}
+	var s string
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("cannot scan type %T into %T", src, m)
+	}
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return fmt.Errorf("invalid multirange literal %q", s)
+	}
+	out := Multirange[T]{}
+	inner := strings.TrimSpace(s[1 : len(s)-1])
+	for inner != "" {
+		end := multirangeItemEnd(inner)
+		if end < 0 {
+			return fmt.Errorf("invalid multirange literal %q", s)
+		}
+		r, err := parseRange[T](inner[:end])
+		if err != nil {
+			return err
+		}
+		out = append(out, r)
+		inner = strings.TrimSpace(inner[end:])
+		if inner != "" {
+			if inner[0] != ',' {
+				return fmt.Errorf("invalid multirange literal %q", s)
+			}
+			inner = strings.TrimSpace(inner[1:])
+		}
+	}
+	*m = out
+	return nil
+}
+
+// multirangeItemEnd returns the length of the range literal at the start of s, or -1.
+func multirangeItemEnd(s string) int {
+	if len(s) >= len("empty") && strings.EqualFold(s[:len("empty")], "empty") {
+		return len("empty")
+	}
+	quoted := false
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+		case !quoted && (c == ')' || c == ']'):
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func (m Multirange[T]) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, r := range m {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		s, err := r.format()
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString(s)
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// Contains reports whether any of the ranges contains v.
+func (m Multirange[T]) Contains(v T) bool {
+	for _, r := range m {
+		if r.Contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Overlaps reports whether any of the ranges overlaps r.
+func (m Multirange[T]) Overlaps(r Range[T]) bool {
+	for _, mr := range m {
+		if mr.Overlaps(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m Multirange[T]) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal([]Range[T](m))
+}
+
+func (m *Multirange[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*m = nil
+		return nil
+	}
+	var out []Range[T]
+	if err := json.Unmarshal(data, &out); err != nil {
+		return err
+	}
+	if out == nil {
+		out = []Range[T]{}
+	}
+	*m = out
+	return nil
+}
+
+func (Multirange[T]) GormDataType() string {
+	return multirangeTypeName[T]()
+}
+
+func (Multirange[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return multirangeTypeName[T]()
+	}
+	return ""
+}
+
+func multirangeTypeName[T any]() string {
+	return strings.TrimSuffix(rangeTypeName[T](), "range") + "multirange"
+}
@@ -0,0 +1,336 @@
+package pgtypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Inet is a PostgreSQL inet value: a host address with an optional netmask, such as 10.0.0.1 or
+// 192.168.0.5/24. The embedded prefix keeps the host bits (Addr returns 192.168.0.5); Masked
+// returns the network. The zero Inet is invalid and is stored as NULL.
+type Inet struct {
+	netip.Prefix
+}
+
+// CIDR is a PostgreSQL cidr value: a network such as 192.168.0.0/24, whose host bits are zero.
+// The zero CIDR is invalid and is stored as NULL.
+type CIDR struct {
+	netip.Prefix
+}
+
+// MacAddr is a PostgreSQL macaddr (6 bytes) or macaddr8 (8 bytes) value.
+type MacAddr net.HardwareAddr
+
+// Named arrays of the network address types.
+type (
+	InetArray    = Array[Inet]
+	CIDRArray    = Array[CIDR]
+	MacAddrArray = Array[MacAddr]
+)
+
+// ParseInet parses an address with an optional netmask; without one the mask covers the whole
+// address (/32 or /128).
+func ParseInet(s string) (Inet, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		p, err := netip.ParsePrefix(s)
+		return Inet{p}, err
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return Inet{}, err
+	}
+	return InetFromAddr(addr), nil
+}
+
+// InetFromAddr returns the inet value of a single host address.
+func InetFromAddr(addr netip.Addr) Inet {
+	return Inet{netip.PrefixFrom(addr, addr.BitLen())}
+}
+
+// ParseCIDR parses a network. Like PostgreSQL it rejects values with bits set to the right of
+// the netmask, and an address without a netmask is a single-host network.
+func ParseCIDR(s string) (CIDR, error) {
+	i, err := ParseInet(s)
+	if err != nil {
+		return CIDR{}, err
+	}
+	if i.Prefix != i.Masked() {
+		return CIDR{}, fmt.Errorf("invalid cidr value %q: value has bits set to right of mask", s)
+	}
+	return CIDR{i.Prefix}, nil
+}
+
+// ParseMacAddr parses a MAC address in any format net.ParseMAC accepts (08:00:2b:01:02:03,
+// 08-00-2b-01-02-03, 0800.2b01.0203), as well as PostgreSQL's 08002b:010203 and 08002b010203.
+func ParseMacAddr(s string) (MacAddr, error) {
+	s = strings.TrimSpace(s)
+	if hw, err := net.ParseMAC(s); err == nil {
+		return MacAddr(hw), nil
+	}
+	digits := strings.NewReplacer(":", "", "-", "", ".", "").Replace(s)
+	if len(digits) != 12 && len(digits) != 16 {
+		return nil, fmt.Errorf("invalid MAC address %q", s)
+	}
+	pairs := make([]string, len(digits)/2)
+	for i := range pairs {
+		pairs[i] = digits[2*i : 2*i+2]
+	}
+	hw, err := net.ParseMAC(strings.Join(pairs, ":"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAC address %q", s)
+	}
+	return MacAddr(hw), nil
+}
+
+// String returns the address as PostgreSQL writes it: without the netmask when it covers the
+// whole address. It is empty for the zero Inet.
+func (i Inet) String() string {
+	if !i.IsValid() {
+		return ""
+	}
+	if i.Bits() == i.Addr().BitLen() {
+		return i.Addr().String()
+	}
+	return i.Prefix.String()
+}
+
+// Contains reports whether addr is in the network of i, as PostgreSQL's i >>= addr.
+func (i Inet) Contains(addr netip.Addr) bool {
+	return i.Prefix.Contains(addr)
+}
+
+// ContainsInet reports whether the network of other lies within the network of i, as
+// PostgreSQL's i >>= other.
+func (i Inet) ContainsInet(other Inet) bool {
+	return i.IsValid() && other.IsValid() && i.Bits() <= other.Bits() && i.Prefix.Contains(other.Addr())
+}
+
+func (i *Inet) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*i = Inet{}
+	case []byte:
+		*i, err = ParseInet(string(v))
+	case string:
+		*i, err = ParseInet(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into Inet", src)
+	}
+	return err
+}
+
+func (i Inet) Value() (driver.Value, error) {
+	if !i.IsValid() {
+		return nil, nil
+	}
+	return i.String(), nil
+}
+
+func (i Inet) MarshalJSON() ([]byte, error) {
+	if !i.IsValid() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(i.String())
+}
+
+func (i *Inet) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*i = Inet{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return i.Scan(s)
+}
+
+func (i Inet) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+func (i *Inet) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*i = Inet{}
+		return nil
+	}
+	return i.Scan(string(data))
+}
+
+func (Inet) GormDataType() string {
+	return "inet"
+}
+
+func (Inet) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "inet"
+	}
+	return ""
+}
+
+// String returns the network with its netmask, as PostgreSQL writes it. It is empty for the
+// zero CIDR.
+func (c CIDR) String() string {
+	if !c.IsValid() {
+		return ""
+	}
+	return c.Prefix.String()
+}
+
+// Contains reports whether addr is in the network, as PostgreSQL's c >>= addr.
+func (c CIDR) Contains(addr netip.Addr) bool {
+	return c.Prefix.Contains(addr)
+}
+
+// ContainsCIDR reports whether other is a subnet of c or c itself, as PostgreSQL's c >>= other.
+func (c CIDR) ContainsCIDR(other CIDR) bool {
+	return c.IsValid() && other.IsValid() && c.Bits() <= other.Bits() && c.Prefix.Contains(other.Addr())
+}
+
+func (c *CIDR) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*c = CIDR{}
+	case []byte:
+		*c, err = ParseCIDR(string(v))
+	case string:
+		*c, err = ParseCIDR(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into CIDR", src)
+	}
+	return err
+}
+
+func (c CIDR) Value() (driver.Value, error) {
+	if !c.IsValid() {
+		return nil, nil
+	}
+	return c.String(), nil
+}
+
+func (c CIDR) MarshalJSON() ([]byte, error) {
+	if !c.IsValid() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(c.String())
+}
+
+func (c *CIDR) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*c = CIDR{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return c.Scan(s)
+}
+
+func (c CIDR) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *CIDR) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*c = CIDR{}
+		return nil
+	}
+	return c.Scan(string(data))
+}
+
+func (CIDR) GormDataType() string {
+	return "cidr"
+}
+
+func (CIDR) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "cidr"
+	}
+	return ""
+}
+
+// String returns the address as colon-separated lowercase hex (08:00:2b:01:02:03).
+func (m MacAddr) String() string {
+	return net.HardwareAddr(m).String()
+}
+
+// Equal reports whether both addresses have the same bytes.
+func (m MacAddr) Equal(other MacAddr) bool {
+	return string(m) == string(other)
+}
+
+func (m *MacAddr) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*m = nil
+	case []byte:
+		*m, err = ParseMacAddr(string(v))
+	case string:
+		*m, err = ParseMacAddr(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into MacAddr", src)
+	}
+	return err
+}
+
+func (m MacAddr) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return m.String(), nil
+}
+
+func (m MacAddr) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(m.String())
+}
+
+func (m *MacAddr) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*m = nil
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return m.Scan(s)
+}
+
+func (m MacAddr) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *MacAddr) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*m = nil
+		return nil
+	}
+	return m.Scan(string(data))
+}
+
+// GormDataType returns macaddr; 8-byte addresses need a macaddr8 column.
+func (MacAddr) GormDataType() string {
+	return "macaddr"
+}
+
+func (MacAddr) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "macaddr"
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package pgtypes
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+)
+
+func TestInet(t *testing.T) {
+	var i Inet
+	if err := i.Scan([]byte("192.168.0.5/24")); err != nil || i.Addr().String() != "192.168.0.5" || i.Bits() != 24 {
+		t.Fatalf("scan: %v (%v)", i, err)
+	}
+	if !i.Contains(netip.MustParseAddr("192.168.0.200")) || i.Contains(netip.MustParseAddr("192.168.1.1")) {
+		t.Fatalf("contains: %v", i)
+	}
+	host, _ := ParseInet("192.168.0.9")
+	if host.String() != "192.168.0.9" || !i.ContainsInet(host) || host.ContainsInet(i) {
+		t.Fatalf("contains inet: %v %v", i, host)
+	}
+	if v, _ := i.Value(); v != "192.168.0.5/24" {
+		t.Fatalf("value: %v", v)
+	}
+	b, _ := json.Marshal(host)
+	var back Inet
+	if string(b) != `"192.168.0.9"` || json.Unmarshal(b, &back) != nil || back != host {
+		t.Fatalf("json: %s -> %v", b, back)
+	}
+	if v, _ := (Inet{}).Value(); v != nil {
+		t.Fatalf("zero value: %v", v)
+	}
+	if err := i.Scan(nil); err != nil || i.IsValid() {
+		t.Fatalf("scan NULL: %v (%v)", i, err)
+	}
+}
+
+func TestCIDR(t *testing.T) {
+	if _, err := ParseCIDR("192.168.0.5/24"); err == nil {
+		t.Fatal("expected error for host bits")
+	}
+	c, err := ParseCIDR("10.0.0.0/8")
+	if err != nil || !c.Contains(netip.MustParseAddr("10.1.2.3")) {
+		t.Fatalf("parse: %v (%v)", c, err)
+	}
+	sub, _ := ParseCIDR("10.20.0.0/16")
+	if !c.ContainsCIDR(sub) || !c.ContainsCIDR(c) || sub.ContainsCIDR(c) {
+		t.Fatalf("contains cidr: %v %v", c, sub)
+	}
+	if host, _ := ParseCIDR("::1"); host.String() != "::1/128" {
+		t.Fatalf("host: %v", host)
+	}
+}
+
+func TestMacAddr(t *testing.T) {
+	want := "08:00:2b:01:02:03"
+	for _, in := range []string{"08:00:2b:01:02:03", "08-00-2B-01-02-03", "0800.2b01.0203", "08002b:010203", "08002b-010203", "08002b010203"} {
+		var m MacAddr
+		if err := m.Scan(in); err != nil || m.String() != want {
+			t.Errorf("scan %s: %v (%v)", in, m, err)
+		}
+	}
+	m8, err := ParseMacAddr("08:00:2b:01:02:03:04:05")
+	if err != nil || len(m8) != 8 {
+		t.Fatalf("macaddr8: %v (%v)", m8, err)
+	}
+	if _, err := ParseMacAddr("08:00:2b"); err == nil {
+		t.Fatal("expected error for short address")
+	}
+	var a MacAddrArray
+	if err := a.Scan(`{08:00:2b:01:02:03,08002b010204}`); err != nil || !a.Contains(MacAddr{8, 0, 0x2b, 1, 2, 4}) {
+		t.Fatalf("macaddr[]: %v (%v)", a, err)
+	}
+	if v, _ := a.Value(); v != "{08:00:2b:01:02:03,08:00:2b:01:02:04}" || a.GormDataType() != "macaddr[]" {
+		t.Fatalf("macaddr[] value: %v", v)
+	}
+}
+
+func TestNetworkArrays(t *testing.T) {
+	var ips InetArray
+	if err := ips.Scan(`{10.0.0.1,192.168.0.5/24,::1}`); err != nil || ips[1].Bits() != 24 {
+		t.Fatalf("inet[]: %v (%v)", ips, err)
+	}
+	if v, _ := ips.Value(); v != "{10.0.0.1,192.168.0.5/24,::1}" || ips.GormDataType() != "inet[]" {
+		t.Fatalf("inet[] value: %v", v)
+	}
+	var nets CIDRArray
+	if err := nets.Scan(`{10.0.0.1/8}`); err == nil {
+		t.Fatal("expected error for host bits")
+	}
+	if err := nets.Scan(`{10.0.0.0/8,fe80::/10}`); err != nil || nets.String() != "10.0.0.0/8,fe80::/10" {
+		t.Fatalf("cidr[]: %v (%v)", nets, err)
+	}
+}
@@ -0,0 +1,151 @@
+package pgtypes
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// NullableArray is a one-dimensional PostgreSQL array of T whose elements may be NULL, which are
+// nil. It converts its elements like Array[T] and keeps NULLs on Scan and Value, so {a,NULL,c}
+// round-trips unchanged.
+type NullableArray[T any] []*T
+
+// NewNullableArray returns a NullableArray holding values, none of them NULL.
+func NewNullableArray[T any](values ...T) NullableArray[T] {
+	out := make(NullableArray[T], len(values))
+	for i := range values {
+		out[i] = &values[i]
+	}
+	return out
+}
+
+func (a *NullableArray[T]) Scan(src interface{}) error {
+	if src == nil {
+		*a = nil
+		return nil
+	}
+	codec := arrayCodecFor[T]()
+	elems, err := arrayLiteral(src, fmt.Sprintf("%T", *a), codec.delimiter())
+	if err != nil {
+		return err
+	}
+	out := make(NullableArray[T], len(elems))
+	for i, e := range elems {
+		if e == nil {
+			continue
+		}
+		v, err := codec.Parse(*e)
+		if err != nil {
+			return err
+		}
+		out[i] = &v
+	}
+	*a = out
+	return nil
+}
+
+func (a NullableArray[T]) Value() (driver.Value, error) {
+	codec := arrayCodecFor[T]()
+	elems := make([]*string, len(a))
+	for i, v := range a {
+		if v == nil {
+			continue
+		}
+		s, err := codec.Format(*v)
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = &s
+	}
+	return formatArray(elems, codec.Quote, codec.delimiter()), nil
+}
+
+func (NullableArray[T]) GormDataType() string {
+	return Array[T]{}.GormDataType()
+}
+
+func (NullableArray[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return Array[T]{}.GormDBDataType(db, field)
+}
+
+// GormValue writes the array as Value does, cast like Array[T].GormValue.
+func (a NullableArray[T]) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	v, err := a.Value()
+	if err != nil {
+		_ = db.AddError(err)
+	}
+	return castArrayValue[T](db, v)
+}
+
+// String writes the elements separated by commas, with NULL for nil elements.
+func (a NullableArray[T]) String() string {
+	strs := make([]string, len(a))
+	for i, v := range a {
+		if v == nil {
+			strs[i] = "NULL"
+			continue
+		}
+		strs[i] = Array[T]{*v}.String()
+	}
+	return strings.Join(strs, ",")
+}
+
+// HasNull reports whether any element is NULL.
+func (a NullableArray[T]) HasNull() bool {
+	for _, v := range a {
+		if v == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Compact returns the non-NULL elements.
+func (a NullableArray[T]) Compact() Array[T] {
+	out := make(Array[T], 0, len(a))
+	for _, v := range a {
+		if v != nil {
+			out = append(out, *v)
+		}
+	}
+	return out
+}
+
+// ValuesOr returns the elements with every NULL replaced by def.
+func (a NullableArray[T]) ValuesOr(def T) Array[T] {
+	out := make(Array[T], len(a))
+	for i, v := range a {
+		out[i] = def
+		if v != nil {
+			out[i] = *v
+		}
+	}
+	return out
+}
+
+func (a NullableArray[T]) Len() int {
+	return len(a)
+}
+
+func (a NullableArray[T]) IsEmpty() bool {
+	return len(a) == 0
+}
+
+// Equals reports whether both arrays have the same elements, NULLs in the same places.
+func (a NullableArray[T]) Equals(b NullableArray[T]) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if (a[i] == nil) != (b[i] == nil) || a[i] != nil && !equalValues(*a[i], *b[i]) {
+			return false
+		}
+	}
+	return true
+}
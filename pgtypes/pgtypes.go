@@ -10,21 +10,93 @@ func DataTypeMap() map[string]func(columnType gorm.ColumnType) string {
 	return map[string]func(columnType gorm.ColumnType) string{
 		"text[]":                        use("pgtypes.StringArray"),
 		"varchar[]":                     use("pgtypes.StringArray"),
+		"character varying[]":           use("pgtypes.StringArray"),
+		"bpchar[]":                      use("pgtypes.StringArray"),
+		"character[]":                   use("pgtypes.StringArray"),
+		"\"char\"[]":                    use("pgtypes.StringArray"),
+		"name[]":                        use("pgtypes.StringArray"),
+		"smallint[]":                    use("pgtypes.Array[int16]"),
+		"int2[]":                        use("pgtypes.Array[int16]"),
 		"integer[]":                     use("pgtypes.Int32Array"),
 		"int4[]":                        use("pgtypes.Int32Array"),
 		"int8[]":                        use("pgtypes.Int64Array"),
 		"bigint[]":                      use("pgtypes.Int64Array"),
+		"oid[]":                         use("pgtypes.Array[uint32]"),
+		"real[]":                        use("pgtypes.Array[float32]"),
+		"float4[]":                      use("pgtypes.Array[float32]"),
+		"float8[]":                      use("pgtypes.Float64Array"),
+		"double precision[]":            use("pgtypes.Float64Array"),
 		"bool[]":                        use("pgtypes.BoolArray"),
 		"boolean[]":                     use("pgtypes.BoolArray"),
 		"uuid[]":                        use("pgtypes.UUIDArray"),
-		"float8[]":                      use("pgtypes.Float64Array"),
-		"double precision[]":            use("pgtypes.Float64Array"),
+		"bytea[]":                       use("pgtypes.Array[[]byte]"),
+		"json[]":                        use("pgtypes.Array[json.RawMessage]"),
+		"jsonb[]":                       use("pgtypes.Array[json.RawMessage]"),
+		"inet[]":                        use("pgtypes.InetArray"),
+		"cidr[]":                        use("pgtypes.CIDRArray"),
+		"macaddr[]":                     use("pgtypes.MacAddrArray"),
+		"macaddr8[]":                    use("pgtypes.MacAddrArray"),
+		"money[]":                       use("pgtypes.MoneyArray"),
+		"bit[]":                         use("pgtypes.BitStringArray"),
+		"bit varying[]":                 use("pgtypes.BitStringArray"),
+		"xml[]":                         use("pgtypes.XMLArray"),
+		"tsvector[]":                    use("pgtypes.TSVectorArray"),
+		"tsquery[]":                     use("pgtypes.TSQueryArray"),
+		"point[]":                       use("pgtypes.Array[pgtypes.Point]"),
+		"line[]":                        use("pgtypes.Array[pgtypes.Line]"),
+		"lseg[]":                        use("pgtypes.Array[pgtypes.LSeg]"),
+		"box[]":                         use("pgtypes.Array[pgtypes.Box]"),
+		"path[]":                        use("pgtypes.Array[pgtypes.Path]"),
+		"polygon[]":                     use("pgtypes.Array[pgtypes.Polygon]"),
+		"circle[]":                      use("pgtypes.Array[pgtypes.Circle]"),
+		"date[]":                        use("pgtypes.DateArray"),
+		"time[]":                        use("pgtypes.StringArray"),
+		"timetz[]":                      use("pgtypes.StringArray"),
+		"time without time zone[]":      use("pgtypes.StringArray"),
+		"time with time zone[]":         use("pgtypes.StringArray"),
 		"timestamptz[]":                 use("pgtypes.TimeArray"),
 		"timestamp[]":                   use("pgtypes.TimeArray"),
 		"timestamp with time zone[]":    use("pgtypes.TimeArray"),
 		"timestamp without time zone[]": use("pgtypes.TimeArray"),
-		"interval":                      use("pgtypes.Duration"),
+		"numeric[]":                     use("pgtypes.DecimalArray"),
 		"interval[]":                    use("pgtypes.DurationArray"),
+		"int4range[]":                   use("pgtypes.Array[pgtypes.Range[int32]]"),
+		"int8range[]":                   use("pgtypes.Array[pgtypes.Range[int64]]"),
+		"numrange[]":                    use("pgtypes.Array[pgtypes.Range[pgtypes.Decimal]]"),
+		"tsrange[]":                     use("pgtypes.Array[pgtypes.Range[time.Time]]"),
+		"tstzrange[]":                   use("pgtypes.Array[pgtypes.Range[time.Time]]"),
+		"daterange[]":                   use("pgtypes.Array[pgtypes.Range[time.Time]]"),
+		"numeric":                       use("pgtypes.Decimal"),
+		"inet":                          use("pgtypes.Inet"),
+		"cidr":                          use("pgtypes.CIDR"),
+		"macaddr":                       use("pgtypes.MacAddr"),
+		"macaddr8":                      use("pgtypes.MacAddr"),
+		"point":                         use("pgtypes.Point"),
+		"line":                          use("pgtypes.Line"),
+		"lseg":                          use("pgtypes.LSeg"),
+		"box":                           use("pgtypes.Box"),
+		"path":                          use("pgtypes.Path"),
+		"polygon":                       use("pgtypes.Polygon"),
+		"circle":                        use("pgtypes.Circle"),
+		"bit":                           use("pgtypes.BitString"),
+		"varbit":                        use("pgtypes.BitString"),
+		"money":                         use("pgtypes.Money"),
+		"xml":                           use("pgtypes.XML"),
+		"tsvector":                      use("pgtypes.TSVector"),
+		"tsquery":                       use("pgtypes.TSQuery"),
+		"interval":                      use("pgtypes.Duration"),
+		"int4range":                     use("pgtypes.Range[int32]"),
+		"int8range":                     use("pgtypes.Range[int64]"),
+		"numrange":                      use("pgtypes.Range[pgtypes.Decimal]"),
+		"tsrange":                       use("pgtypes.Range[time.Time]"),
+		"tstzrange":                     use("pgtypes.Range[time.Time]"),
+		"daterange":                     use("pgtypes.Range[time.Time]"),
+		"int4multirange":                use("pgtypes.Multirange[int32]"),
+		"int8multirange":                use("pgtypes.Multirange[int64]"),
+		"nummultirange":                 use("pgtypes.Multirange[pgtypes.Decimal]"),
+		"tsmultirange":                  use("pgtypes.Multirange[time.Time]"),
+		"tstzmultirange":                use("pgtypes.Multirange[time.Time]"),
+		"datemultirange":                use("pgtypes.Multirange[time.Time]"),
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -90,3 +91,143 @@ func TestUUIDArray_ScanAndValue(t *testing.T) {
 		t.Fatalf("unexpected value: %v", v)
 	}
 }
+
+func TestParseArray(t *testing.T) {
+	elems, err := ParseArray(`{a, "b c" ,NULL,"NULL","d\"e\\f","",x\,y}`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := []*string{ptr("a"), ptr("b c"), nil, ptr("NULL"), ptr(`d"e\f`), ptr(""), ptr("x,y")}
+	if len(elems) != len(want) {
+		t.Fatalf("got %d elements, want %d", len(elems), len(want))
+	}
+	for i := range want {
+		if (elems[i] == nil) != (want[i] == nil) || (elems[i] != nil && *elems[i] != *want[i]) {
+			t.Fatalf("element %d: got %v, want %v", i, deref(elems[i]), deref(want[i]))
+		}
+	}
+	if got := FormatArray(want); got != `{a,"b c",NULL,"NULL","d\"e\\f","","x,y"}` {
+		t.Fatalf("unexpected format: %s", got)
+	}
+	for _, bad := range []string{`{a,"b}`, `a,b`, `{a,,b}`, `{{1,2}}`} {
+		if _, err := ParseArray(bad); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+}
+
+func TestParseRecord(t *testing.T) {
+	fields, err := ParseRecord(`(1,"a b",,"x""y\\z","",(n))`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := []*string{ptr("1"), ptr("a b"), nil, ptr(`x"y\z`), ptr(""), ptr("(n)")}
+	if len(fields) != len(want) {
+		t.Fatalf("got %d fields, want %d", len(fields), len(want))
+	}
+	for i := range want {
+		if (fields[i] == nil) != (want[i] == nil) || (fields[i] != nil && *fields[i] != *want[i]) {
+			t.Fatalf("field %d: got %v, want %v", i, deref(fields[i]), deref(want[i]))
+		}
+	}
+	if got := FormatRecord(want); got != `(1,"a b",,"x""y\\z","","(n)")` {
+		t.Fatalf("unexpected format: %s", got)
+	}
+}
+
+func TestRecordFields(t *testing.T) {
+	var (
+		s  *string
+		n  int32
+		b  *bool
+		ts time.Time
+		bs []byte
+		u  uuid.UUID
+	)
+	for _, c := range []struct {
+		dst  interface{}
+		text *string
+	}{{&s, ptr("hi")}, {&n, ptr("42")}, {&b, ptr("t")}, {&ts, ptr("2024-03-01 10:20:30.5+02")}, {&bs, ptr(`\x0102`)}, {&u, ptr("7d444840-9dc0-11d1-b245-5ffdce74fad2")}} {
+		if err := ScanRecordField(c.dst, c.text); err != nil {
+			t.Fatalf("scan into %T: %v", c.dst, err)
+		}
+	}
+	if *s != "hi" || n != 42 || !*b || ts.Nanosecond() != 500000000 || len(bs) != 2 || u.String() != "7d444840-9dc0-11d1-b245-5ffdce74fad2" {
+		t.Fatalf("unexpected scan results: %v %v %v %v %v %v", *s, n, *b, ts, bs, u)
+	}
+	if err := ScanRecordField(&s, nil); err != nil || s != nil {
+		t.Fatalf("NULL should reset pointer, got %v (%v)", s, err)
+	}
+	for _, c := range []struct {
+		value interface{}
+		want  *string
+	}{{s, nil}, {n, ptr("42")}, {b, ptr("true")}, {bs, ptr(`\x0102`)}, {StringArray{"a b"}, ptr(`{"a b"}`)}} {
+		got, err := FormatRecordField(c.value)
+		if err != nil || deref(got) != deref(c.want) || (got == nil) != (c.want == nil) {
+			t.Fatalf("format %T: got %v (%v), want %v", c.value, deref(got), err, deref(c.want))
+		}
+	}
+}
+
+func ptr(s string) *string { return &s }
+
+func deref(s *string) string {
+	if s == nil {
+		return "<nil>"
+	}
+	return *s
+}
+
+func TestParseArrayLiteral(t *testing.T) {
+	elems, dims, err := ParseArrayLiteral(` { {1, 2 } , {NULL,"4"} } `)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(dims) != 2 || dims[0] != (ArrayDimension{2, 1}) || dims[1] != (ArrayDimension{2, 1}) {
+		t.Fatalf("unexpected dimensions: %v", dims)
+	}
+	if len(elems) != 4 || deref(elems[0]) != "1" || elems[2] != nil || deref(elems[3]) != "4" {
+		t.Fatalf("unexpected elements: %v", elems)
+	}
+	if s, err := FormatArrayLiteral(elems, dims); err != nil || s != "{{1,2},{NULL,4}}" {
+		t.Fatalf("unexpected format: %s (%v)", s, err)
+	}
+
+	elems, dims, err = ParseArrayLiteral(`[0:2]={a,\NULL,b\ }`)
+	if err != nil || len(dims) != 1 || dims[0] != (ArrayDimension{3, 0}) {
+		t.Fatalf("decorated array: %v %v", dims, err)
+	}
+	if deref(elems[1]) != "NULL" || deref(elems[2]) != "b " {
+		t.Fatalf("escaped elements: %q %q", deref(elems[1]), deref(elems[2]))
+	}
+	if s, _ := FormatArrayLiteral(elems, dims); s != `[0:2]={a,"NULL","b "}` {
+		t.Fatalf("unexpected decorated format: %s", s)
+	}
+
+	if _, dims, err := ParseArrayLiteral("{}"); err != nil || len(dims) != 0 {
+		t.Fatalf("empty array: %v %v", dims, err)
+	}
+	for _, bad := range []string{`{{1,2},{3}}`, `{{1},2}`, `{1,{2}}`, `[1:3]={1,2}`, `{1,2} x`, `{{}}`, `[1:2]{1,2}`} {
+		if _, _, err := ParseArrayLiteral(bad); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+}
+
+func TestStringArray_SpecialCharacters(t *testing.T) {
+	a := StringArray{`a,b`, `say "hi"`, `back\slash`, `{braces}`, ``, `NULL`}
+	v, err := a.Value()
+	if err != nil {
+		t.Fatalf("value: %v", err)
+	}
+	if v != `{"a,b","say \"hi\"","back\\slash","{braces}","","NULL"}` {
+		t.Fatalf("unexpected value: %v", v)
+	}
+	var out StringArray
+	if err := out.Scan(v); err != nil || !out.Equals(a) {
+		t.Fatalf("roundtrip: %q (%v)", out, err)
+	}
+	if err := out.Scan(`{a,NULL}`); err == nil {
+		t.Fatal("expected error for NULL element")
+	}
+}
@@ -0,0 +1,444 @@
+package pgtypes
+
+import (
+	"cmp"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Range is a PostgreSQL range value (int4range, int8range, numrange, tsrange, tstzrange,
+// daterange). A bound is unbounded when its Unbounded flag is set, and -infinity or infinity
+// (for timestamp and date ranges) when its Infinite flag is set; the bound value is then ignored.
+// The zero Range is (0,0), which is empty: set both Unbounded flags for (,), which contains
+// everything, and use EmptyRange for empty. Null is set by Scan for NULL and makes Value return
+// NULL, so that a Range field of a nullable column writes back what it read.
+//
+// Elements are scanned and formatted like composite type fields (see ScanRecordField), so any
+// type those support can be used. Contains and Overlaps additionally need an ordered type,
+// time.Time, or a type with a Compare(T) int method.
+type Range[T any] struct {
+	Lower          T
+	Upper          T
+	LowerInclusive bool
+	UpperInclusive bool
+	LowerUnbounded bool
+	UpperUnbounded bool
+	LowerInfinite  bool // the lower bound is -infinity
+	UpperInfinite  bool // the upper bound is infinity
+	Empty          bool
+	Null           bool
+}
+
+type (
+	Int4Range = Range[int32]
+	Int8Range = Range[int64]
+	NumRange  = Range[Decimal]
+	TsRange   = Range[time.Time]
+	TstzRange = Range[time.Time]
+	DateRange = Range[time.Time]
+)
+
+// NewRange returns the range between lower and upper with bounds given as in PostgreSQL's range
+// constructors: "[)", "[]", "()" or "(]".
+func NewRange[T any](lower, upper T, bounds string) Range[T] {
+	return Range[T]{Lower: lower, Upper: upper, LowerInclusive: strings.HasPrefix(bounds, "["), UpperInclusive: strings.HasSuffix(bounds, "]")}
+}
+
+// EmptyRange returns the empty range.
+func EmptyRange[T any]() Range[T] {
+	return Range[T]{Empty: true}
+}
+
+func (r *Range[T]) Scan(src interface{}) error {
+	if src == nil {
+		*r = Range[T]{Null: true}
+		return nil
+	}
+	var s string
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("cannot scan type %T into %T", src, r)
+	}
+	parsed, err := parseRange[T](s)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+func (r Range[T]) Value() (driver.Value, error) {
+	if r.Null {
+		return nil, nil
+	}
+	return r.format()
+}
+
+// String returns the range literal, e.g. [1,10), or NULL.
+func (r Range[T]) String() string {
+	if r.Null {
+		return "NULL"
+	}
+	s, err := r.format()
+	if err != nil {
+		return fmt.Sprintf("%%!(%v)", err)
+	}
+	return s
+}
+
+// IsEmpty reports whether the range contains no values, either because it is the empty range
+// or because its bounds exclude every value. A NULL range is not empty.
+func (r Range[T]) IsEmpty() bool {
+	if r.Empty {
+		return true
+	}
+	if r.Null || r.lowerOpen() || r.upperOpen() {
+		return false
+	}
+	c := compareRangeValues(r.Lower, r.Upper)
+	return c > 0 || c == 0 && !(r.LowerInclusive && r.UpperInclusive)
+}
+
+// Contains reports whether v lies within the range.
+func (r Range[T]) Contains(v T) bool {
+	if r.Null || r.IsEmpty() {
+		return false
+	}
+	if !r.lowerOpen() {
+		if c := compareRangeValues(r.Lower, v); c > 0 || c == 0 && !r.LowerInclusive {
+			return false
+		}
+	}
+	if !r.upperOpen() {
+		if c := compareRangeValues(v, r.Upper); c > 0 || c == 0 && !r.UpperInclusive {
+			return false
+		}
+	}
+	return true
+}
+
+// Overlaps reports whether the ranges have a value in common, like the && operator. For
+// discrete types ranges are compared as written, e.g. [1,2) and (1,3) do not overlap.
+func (r Range[T]) Overlaps(other Range[T]) bool {
+	if r.Null || other.Null || r.IsEmpty() || other.IsEmpty() {
+		return false
+	}
+	return r.startsBeforeEndOf(other) && other.startsBeforeEndOf(r)
+}
+
+// startsBeforeEndOf reports whether r's lower bound lies before other's upper bound.
+func (r Range[T]) startsBeforeEndOf(other Range[T]) bool {
+	if r.lowerOpen() || other.upperOpen() {
+		return true
+	}
+	c := compareRangeValues(r.Lower, other.Upper)
+	return c < 0 || c == 0 && r.LowerInclusive && other.UpperInclusive
+}
+
+// lowerOpen reports whether the lower bound is below every value of T.
+func (r Range[T]) lowerOpen() bool {
+	return r.LowerUnbounded || r.LowerInfinite
+}
+
+// upperOpen reports whether the upper bound is above every value of T.
+func (r Range[T]) upperOpen() bool {
+	return r.UpperUnbounded || r.UpperInfinite
+}
+
+type rangeJSON[T any] struct {
+	Lower         *T     `json:"lower"`
+	Upper         *T     `json:"upper"`
+	Bounds        string `json:"bounds,omitempty"`
+	LowerInfinite bool   `json:"lower_infinite,omitempty"`
+	UpperInfinite bool   `json:"upper_infinite,omitempty"`
+	Empty         bool   `json:"empty,omitempty"`
+}
+
+// MarshalJSON encodes the range as {"lower":1,"upper":10,"bounds":"[)"}, with null for an
+// unbounded or infinite side and "lower_infinite"/"upper_infinite" marking the latter, as
+// {"lower":null,"upper":null,"empty":true}, or as null for a NULL range.
+func (r Range[T]) MarshalJSON() ([]byte, error) {
+	if r.Null {
+		return []byte("null"), nil
+	}
+	if r.Empty {
+		return json.Marshal(rangeJSON[T]{Empty: true})
+	}
+	out := rangeJSON[T]{Bounds: r.bounds(), LowerInfinite: r.LowerInfinite, UpperInfinite: r.UpperInfinite}
+	if !r.lowerOpen() {
+		out.Lower = &r.Lower
+	}
+	if !r.upperOpen() {
+		out.Upper = &r.Upper
+	}
+	return json.Marshal(out)
+}
+
+func (r *Range[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*r = Range[T]{Null: true}
+		return nil
+	}
+	var in rangeJSON[T]
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	if in.Empty {
+		*r = EmptyRange[T]()
+		return nil
+	}
+	if in.Bounds == "" {
+		in.Bounds = "[)"
+	}
+	if len(in.Bounds) != 2 || !strings.Contains("[(", in.Bounds[:1]) || !strings.Contains("])", in.Bounds[1:]) {
+		return fmt.Errorf("invalid range bounds %q", in.Bounds)
+	}
+	var zero T
+	out := NewRange(zero, zero, in.Bounds)
+	switch {
+	case in.LowerInfinite:
+		out.LowerInfinite = true
+	case in.Lower == nil:
+		out.LowerUnbounded, out.LowerInclusive = true, false
+	default:
+		out.Lower = *in.Lower
+	}
+	switch {
+	case in.UpperInfinite:
+		out.UpperInfinite = true
+	case in.Upper == nil:
+		out.UpperUnbounded, out.UpperInclusive = true, false
+	default:
+		out.Upper = *in.Upper
+	}
+	*r = out
+	return nil
+}
+
+func (r Range[T]) MarshalText() ([]byte, error) {
+	s, err := r.format()
+	return []byte(s), err
+}
+
+func (r *Range[T]) UnmarshalText(data []byte) error {
+	return r.Scan(string(data))
+}
+
+func (Range[T]) GormDataType() string {
+	return rangeTypeName[T]()
+}
+
+func (Range[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return rangeTypeName[T]()
+	}
+	return ""
+}
+
+// rangeTypeName guesses the PostgreSQL range type for T; time.Time maps to tstzrange.
+func rangeTypeName[T any]() string {
+	var zero T
+	switch any(zero).(type) {
+	case int32:
+		return "int4range"
+	case int64:
+		return "int8range"
+	case time.Time:
+		return "tstzrange"
+	}
+	return "numrange"
+}
+
+func (r Range[T]) bounds() string {
+	b := []byte("()")
+	if r.LowerInclusive && !r.LowerUnbounded {
+		b[0] = '['
+	}
+	if r.UpperInclusive && !r.UpperUnbounded {
+		b[1] = ']'
+	}
+	return string(b)
+}
+
+func (r Range[T]) format() (string, error) {
+	if r.Empty {
+		return "empty", nil
+	}
+	var b strings.Builder
+	bounds := r.bounds()
+	b.WriteByte(bounds[0])
+	if r.LowerInfinite {
+		b.WriteString("-infinity")
+	} else if !r.LowerUnbounded {
+		if err := writeRangeBound(&b, r.Lower); err != nil {
+			return "", err
+		}
+	}
+	b.WriteByte(',')
+	if r.UpperInfinite {
+		b.WriteString("infinity")
+	} else if !r.UpperUnbounded {
+		if err := writeRangeBound(&b, r.Upper); err != nil {
+			return "", err
+		}
+	}
+	b.WriteByte(bounds[1])
+	return b.String(), nil
+}
+
+func writeRangeBound(b *strings.Builder, v any) error {
+	text, err := FormatRecordField(v)
+	if err != nil {
+		return err
+	}
+	if text == nil {
+		return fmt.Errorf("range bound %v has no value", v)
+	}
+	s := *text
+	if s != "" && !strings.ContainsAny(s, "()[],\"\\ \t\n\r\v\f") {
+		b.WriteString(s)
+		return nil
+	}
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('"')
+	return nil
+}
+
+// parseRange parses a range literal such as [1,10), ("2024-01-01 00:00:00+00",) or empty.
+// A -infinity lower or infinity upper bound that T cannot hold, as for time.Time, sets the
+// Infinite flag of the bound.
+func parseRange[T any](s string) (Range[T], error) {
+	var r Range[T]
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "empty") {
+		return EmptyRange[T](), nil
+	}
+	if len(s) < 3 || !strings.Contains("[(", s[:1]) || !strings.Contains("])", s[len(s)-1:]) {
+		return r, fmt.Errorf("invalid range literal %q", s)
+	}
+	r.LowerInclusive, r.UpperInclusive = s[0] == '[', s[len(s)-1] == ']'
+	lower, rest, err := readRangeBound(s[1:len(s)-1], ',')
+	if err != nil {
+		return r, fmt.Errorf("invalid range literal %q: %w", s, err)
+	}
+	upper, rest, err := readRangeBound(rest, 0)
+	if err != nil || rest != "" {
+		return r, fmt.Errorf("invalid range literal %q", s)
+	}
+	if lower == nil {
+		r.LowerUnbounded, r.LowerInclusive = true, false
+	} else if err := ScanRecordField(&r.Lower, lower); err != nil {
+		if !strings.EqualFold(*lower, "-infinity") {
+			return r, fmt.Errorf("range lower bound: %w", err)
+		}
+		r.LowerInfinite = true
+	}
+	if upper == nil {
+		r.UpperUnbounded, r.UpperInclusive = true, false
+	} else if err := ScanRecordField(&r.Upper, upper); err != nil {
+		if !strings.EqualFold(*upper, "infinity") {
+			return r, fmt.Errorf("range upper bound: %w", err)
+		}
+		r.UpperInfinite = true
+	}
+	return r, nil
+}
+
+// readRangeBound reads one bound up to sep (0 for the end of s) and returns the rest after it.
+// An empty, unquoted bound is returned as nil.
+func readRangeBound(s string, sep byte) (*string, string, error) {
+	var b strings.Builder
+	quoted := false
+	i := 0
+	for ; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"':
+			if quoted && i+1 < len(s) && s[i+1] == '"' {
+				b.WriteByte('"')
+				i++
+				continue
+			}
+			quoted = !quoted
+			continue
+		case c == '\\':
+			i++
+			if i == len(s) {
+				return nil, "", fmt.Errorf("trailing backslash")
+			}
+			b.WriteByte(s[i])
+			continue
+		case !quoted && sep != 0 && c == sep:
+			return rangeBoundText(b.String(), i > 0), s[i+1:], nil
+		}
+		b.WriteByte(c)
+	}
+	if quoted {
+		return nil, "", fmt.Errorf("unterminated quote")
+	}
+	if sep != 0 {
+		return nil, "", fmt.Errorf("missing %q", sep)
+	}
+	return rangeBoundText(b.String(), i > 0), "", nil
+}
+
+func rangeBoundText(text string, present bool) *string {
+	if !present {
+		return nil
+	}
+	return &text
+}
+
+// compareRangeValues orders two bound values. It panics for types without an ordering.
+func compareRangeValues[T any](a, b T) int {
+	if c, ok := compareValues(a, b); ok {
+		return c
+	}
+	panic(fmt.Sprintf("pgtypes: range bounds of type %T cannot be compared", a))
+}
+
+// compareValues orders two values through a Compare method or their basic kind (false sorts
+// before true). ok is false for types without an ordering.
+func compareValues[T any](a, b T) (c int, ok bool) {
+	if c, ok := any(a).(interface{ Compare(T) int }); ok {
+		return c.Compare(b), true
+	}
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch va.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(va.Int(), vb.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmp.Compare(va.Uint(), vb.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(va.Float(), vb.Float()), true
+	case reflect.String:
+		return cmp.Compare(va.String(), vb.String()), true
+	case reflect.Bool:
+		x, y := 0, 0
+		if va.Bool() {
+			x = 1
+		}
+		if vb.Bool() {
+			y = 1
+		}
+		return cmp.Compare(x, y), true
+	}
+	return 0, false
+}
@@ -0,0 +1,154 @@
+package pgtypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestRange_ScanAndValue(t *testing.T) {
+	for _, c := range []struct{ in, out string }{
+		{"[1,10)", "[1,10)"},
+		{"(,5]", "(,5]"},
+		{"[3,)", "[3,)"},
+		{"(,)", "(,)"},
+		{"empty", "empty"},
+		{`["-2","4"]`, "[-2,4]"},
+	} {
+		var r Range[int32]
+		if err := r.Scan([]byte(c.in)); err != nil {
+			t.Fatalf("scan %s: %v", c.in, err)
+		}
+		if v, err := r.Value(); err != nil || v != c.out {
+			t.Fatalf("value of %s: got %v (%v), want %s", c.in, v, err, c.out)
+		}
+	}
+	var ts Range[time.Time]
+	if err := ts.Scan(`["2024-01-01 00:00:00+00","2024-02-01 12:30:00+00")`); err != nil {
+		t.Fatalf("scan tstzrange: %v", err)
+	}
+	if ts.Lower.Month() != time.January || ts.Upper.Hour() != 12 || !ts.LowerInclusive || ts.UpperInclusive {
+		t.Fatalf("unexpected tstzrange: %+v", ts)
+	}
+	if v, _ := ts.Value(); v != `["2024-01-01 00:00:00Z","2024-02-01 12:30:00Z")` {
+		t.Fatalf("unexpected tstzrange value: %v", v)
+	}
+	var d Range[time.Time]
+	if err := d.Scan("[2024-01-01,infinity)"); err != nil || !d.UpperInfinite || d.UpperUnbounded || d.Lower.Day() != 1 {
+		t.Fatalf("unexpected daterange: %+v (%v)", d, err)
+	}
+	if v, _ := d.Value(); v != `["2024-01-01 00:00:00Z",infinity)` {
+		t.Fatalf("unexpected daterange value: %v", v)
+	}
+	if !d.Contains(time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)) || d.Overlaps(NewRange(time.Time{}, d.Lower, "[)")) {
+		t.Fatalf("unexpected infinite bound comparisons for %s", d)
+	}
+	if err := d.Scan("(-infinity,)"); err != nil || !d.LowerInfinite || !d.UpperUnbounded {
+		t.Fatalf("unexpected daterange: %+v (%v)", d, err)
+	}
+	if js, _ := json.Marshal(d); string(js) != `{"lower":null,"upper":null,"bounds":"()","lower_infinite":true}` {
+		t.Fatalf("unexpected daterange JSON: %s", js)
+	}
+	var back Range[time.Time]
+	if err := json.Unmarshal([]byte(`{"lower":null,"upper":null,"bounds":"()","lower_infinite":true}`), &back); err != nil || back != d {
+		t.Fatalf("JSON round trip: %+v (%v)", back, err)
+	}
+
+	var null Range[int32]
+	if err := null.Scan(nil); err != nil || !null.Null {
+		t.Fatalf("scan NULL: %+v (%v)", null, err)
+	}
+	if v, err := null.Value(); err != nil || v != nil {
+		t.Fatalf("NULL range value: %v (%v)", v, err)
+	}
+	if js, _ := json.Marshal(null); string(js) != "null" {
+		t.Fatalf("NULL range JSON: %s", js)
+	}
+	var zero Range[int32]
+	if !zero.IsEmpty() || zero.String() != "(0,0)" {
+		t.Fatalf("unexpected zero range %s", zero)
+	}
+	for _, bad := range []string{"", "[1,2", "1,2)", "[1)", `["1,2)`} {
+		if err := new(Range[int32]).Scan(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestRange_ContainsOverlaps(t *testing.T) {
+	r := NewRange[int64](1, 10, "[)")
+	if !r.Contains(1) || r.Contains(10) || !r.Contains(9) || r.Contains(0) {
+		t.Fatalf("unexpected Contains results for %s", r)
+	}
+	unbounded := Range[int64]{LowerUnbounded: true, Upper: 0}
+	if !unbounded.Contains(-1000) || unbounded.Contains(0) {
+		t.Fatalf("unexpected Contains results for %s", unbounded)
+	}
+	if EmptyRange[int64]().Contains(0) || !NewRange[int64](5, 5, "[)").IsEmpty() || NewRange[int64](5, 5, "[]").IsEmpty() {
+		t.Fatal("unexpected emptiness")
+	}
+	for _, c := range []struct {
+		a, b Range[int64]
+		want bool
+	}{
+		{r, NewRange[int64](10, 20, "[)"), false},
+		{r, NewRange[int64](9, 20, "[)"), true},
+		{NewRange[int64](1, 10, "[]"), NewRange[int64](10, 20, "[)"), true},
+		{r, Range[int64]{LowerUnbounded: true, UpperUnbounded: true}, true},
+		{r, EmptyRange[int64](), false},
+	} {
+		if got := c.a.Overlaps(c.b); got != c.want || c.b.Overlaps(c.a) != c.want {
+			t.Fatalf("%s && %s: got %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	if !NewRange(day(1), day(5), "[)").Contains(day(4)) {
+		t.Fatal("time range should contain its inner days")
+	}
+}
+
+func TestRange_JSON(t *testing.T) {
+	for _, c := range []struct {
+		r    Range[int32]
+		want string
+	}{
+		{NewRange[int32](1, 10, "[)"), `{"lower":1,"upper":10,"bounds":"[)"}`},
+		{Range[int32]{Lower: 3, LowerInclusive: true, UpperUnbounded: true}, `{"lower":3,"upper":null,"bounds":"[)"}`},
+		{EmptyRange[int32](), `{"lower":null,"upper":null,"empty":true}`},
+	} {
+		b, err := json.Marshal(c.r)
+		if err != nil || string(b) != c.want {
+			t.Fatalf("marshal %s: got %s (%v), want %s", c.r, b, err, c.want)
+		}
+		var out Range[int32]
+		if err := json.Unmarshal(b, &out); err != nil || out != c.r {
+			t.Fatalf("roundtrip %s: got %s (%v)", c.r, out, err)
+		}
+	}
+}
+
+func TestMultirange(t *testing.T) {
+	var m Multirange[int32]
+	if err := m.Scan("{[1,3), [5,7), empty}"); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if len(m) != 3 || !m.Contains(6) || m.Contains(4) || !m.Overlaps(NewRange[int32](2, 4, "[)")) {
+		t.Fatalf("unexpected multirange: %v", m)
+	}
+	if v, err := m.Value(); err != nil || v != "{[1,3),[5,7),empty}" {
+		t.Fatalf("unexpected value: %v (%v)", v, err)
+	}
+	if err := m.Scan("{}"); err != nil || m == nil || len(m) != 0 {
+		t.Fatalf("empty multirange: %v (%v)", m, err)
+	}
+	if err := m.Scan(nil); err != nil || m != nil {
+		t.Fatalf("NULL multirange: %v (%v)", m, err)
+	}
+	if v, _ := m.Value(); v != nil {
+		t.Fatalf("nil multirange should be NULL, got %v", v)
+	}
+	b, _ := json.Marshal(Multirange[int32]{NewRange[int32](1, 2, "[)")})
+	if string(b) != `[{"lower":1,"upper":2,"bounds":"[)"}]` {
+		t.Fatalf("unexpected json: %s", b)
+	}
+}
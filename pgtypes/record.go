@@ -0,0 +1,253 @@
+package pgtypes
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseRecord splits a PostgreSQL row literal such as (1,"a b",,"x""y") into its fields.
+// Quoted fields are unescaped; an empty unquoted field is NULL and returned as nil.
+func ParseRecord(s string) ([]*string, error) {
+	if len(s) < 2 || s[0] != '(' || s[len(s)-1] != ')' {
+		return nil, fmt.Errorf("invalid record literal %q", s)
+	}
+	inner := s[1 : len(s)-1]
+	var fields []*string
+	for i := 0; ; {
+		var b strings.Builder
+		quoted, inQuotes := false, false
+		for ; i < len(inner) && (inQuotes || inner[i] != ','); i++ {
+			switch c := inner[i]; {
+			case c == '\\':
+				i++
+				if i == len(inner) {
+					return nil, fmt.Errorf("trailing backslash in record literal %q", s)
+				}
+				b.WriteByte(inner[i])
+			case c == '"' && inQuotes && i+1 < len(inner) && inner[i+1] == '"':
+				b.WriteByte('"')
+				i++
+			case c == '"':
+				quoted, inQuotes = true, !inQuotes
+			default:
+				b.WriteByte(c)
+			}
+		}
+		if inQuotes {
+			return nil, fmt.Errorf("unterminated quoted field in record literal %q", s)
+		}
+		if field := b.String(); quoted || field != "" {
+			fields = append(fields, &field)
+		} else {
+			fields = append(fields, nil)
+		}
+		if i == len(inner) {
+			return fields, nil
+		}
+		i++ // skip the comma
+	}
+}
+
+// FormatRecord builds a PostgreSQL row literal from its fields, quoting them when needed.
+// nil fields are written as NULL (an empty field).
+func FormatRecord(fields []*string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for i, f := range fields {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if f == nil {
+			continue
+		}
+		s := *f
+		if s != "" && !strings.ContainsAny(s, "(),\"\\ \t\n\r\v\f") {
+			b.WriteString(s)
+			continue
+		}
+		b.WriteByte('"')
+		for j := 0; j < len(s); j++ {
+			if s[j] == '"' || s[j] == '\\' {
+				b.WriteByte(s[j])
+			}
+			b.WriteByte(s[j])
+		}
+		b.WriteByte('"')
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// ScanRecordField stores the text of a record field (nil for NULL) in dst, which must be a
+// pointer. Pointer targets are allocated as needed and set to nil for NULL. Types implementing
+// sql.Scanner are handed the text; strings, numbers, booleans, time.Time and []byte (bytea hex
+// format) are parsed directly.
+func ScanRecordField(dst interface{}, text *string) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("ScanRecordField: destination must be a non-nil pointer, got %T", dst)
+	}
+	return scanRecordValue(v.Elem(), text)
+}
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+func scanRecordValue(v reflect.Value, text *string) error {
+	if text == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return scanRecordValue(v.Elem(), text)
+	}
+	if reflect.PointerTo(v.Type()).Implements(scannerType) {
+		return v.Addr().Interface().(sql.Scanner).Scan(*text)
+	}
+	s := *text
+	switch v.Interface().(type) {
+	case time.Time:
+		t, err := parseRecordTime(s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case []byte:
+		if !strings.HasPrefix(s, `\x`) {
+			return fmt.Errorf("unsupported bytea format %q", s)
+		}
+		b, err := hex.DecodeString(s[2:])
+		if err != nil {
+			return err
+		}
+		v.SetBytes(b)
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		switch s {
+		case "t", "true":
+			v.SetBool(true)
+		case "f", "false":
+			v.SetBool(false)
+		default:
+			return fmt.Errorf("invalid boolean %q", s)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("cannot scan record field into %s", v.Type())
+	}
+	return nil
+}
+
+var recordTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07:00:00",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+	"15:04:05.999999999",
+	"15:04:05.999999999Z07:00",
+	"15:04:05.999999999Z07",
+	time.RFC3339Nano,
+}
+
+func parseRecordTime(s string) (time.Time, error) {
+	for _, layout := range recordTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
+
+// FormatRecordField returns the text of a record field for FormatRecord, or nil for NULL.
+// It is the counterpart of ScanRecordField: driver.Valuer results are used as-is, other values
+// are formatted the way PostgreSQL accepts them as input.
+func FormatRecordField(value interface{}) (*string, error) {
+	v := reflect.ValueOf(value)
+	for v.IsValid() && v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, nil
+		}
+		if valuer, ok := v.Interface().(driver.Valuer); ok {
+			return formatDriverValue(valuer)
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if valuer, ok := v.Interface().(driver.Valuer); ok {
+		return formatDriverValue(valuer)
+	}
+	var s string
+	switch x := v.Interface().(type) {
+	case time.Time:
+		s = x.Format("2006-01-02 15:04:05.999999999Z07:00")
+	case []byte:
+		if x == nil {
+			return nil, nil
+		}
+		s = `\x` + hex.EncodeToString(x)
+	default:
+		switch v.Kind() {
+		case reflect.String:
+			s = v.String()
+		case reflect.Bool:
+			s = strconv.FormatBool(v.Bool())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			s = strconv.FormatInt(v.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			s = strconv.FormatUint(v.Uint(), 10)
+		case reflect.Float32, reflect.Float64:
+			s = strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+		default:
+			return nil, fmt.Errorf("cannot format %T as a record field", value)
+		}
+	}
+	return &s, nil
+}
+
+func formatDriverValue(valuer driver.Valuer) (*string, error) {
+	dv, err := valuer.Value()
+	if err != nil || dv == nil {
+		return nil, err
+	}
+	switch x := dv.(type) {
+	case []byte:
+		s := string(x)
+		return &s, nil
+	case string:
+		return &x, nil
+	}
+	return FormatRecordField(dv)
+}
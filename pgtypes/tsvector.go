@@ -0,0 +1,332 @@
+package pgtypes
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// TSVector is a PostgreSQL tsvector: a list of lexemes, each with the positions it occurs at in
+// the document, such as 'a':1A 'cat':2,5. A nil TSVector is stored as NULL.
+type TSVector []TSLexeme
+
+// TSLexeme is a normalized word of a TSVector with its positions, which may be empty.
+type TSLexeme struct {
+	Word      string
+	Positions []TSPosition
+}
+
+// TSPosition is the position of a lexeme, from 1 to 16383, with its weight: 'A', 'B', 'C' or
+// 'D'. The zero weight is treated as 'D', the default.
+type TSPosition struct {
+	Position int
+	Weight   byte
+}
+
+// TSQuery is a PostgreSQL tsquery in its text format, such as 'fat' & ( 'rat' | 'cat' ). Build
+// one with TSTerm and the combining methods, which quote and group their operands.
+type TSQuery string
+
+// Named arrays of the full-text search types.
+type (
+	TSVectorArray = Array[TSVector]
+	TSQueryArray  = Array[TSQuery]
+)
+
+// ParseTSVector parses the tsvector text format. Words may be quoted with single quotes, a
+// doubled quote or a backslash escaping the next character; positions follow a colon.
+func ParseTSVector(s string) (TSVector, error) {
+	v := TSVector{}
+	for i := 0; ; {
+		for i < len(s) && isArraySpace(s[i]) {
+			i++
+		}
+		if i == len(s) {
+			return v, nil
+		}
+		var word strings.Builder
+		if s[i] == '\'' {
+			for i++; ; i++ {
+				if i == len(s) {
+					return nil, fmt.Errorf("invalid tsvector %q: unterminated quoted string", s)
+				}
+				if s[i] == '\'' {
+					if i+1 < len(s) && s[i+1] == '\'' {
+						i++
+					} else {
+						i++
+						break
+					}
+				} else if s[i] == '\\' && i+1 < len(s) {
+					i++
+				}
+				word.WriteByte(s[i])
+			}
+		} else {
+			for ; i < len(s) && !isArraySpace(s[i]) && s[i] != ':'; i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+				}
+				word.WriteByte(s[i])
+			}
+		}
+		lexeme := TSLexeme{Word: word.String()}
+		if i < len(s) && s[i] == ':' {
+			for {
+				i++
+				start := i
+				for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+					i++
+				}
+				pos, err := strconv.Atoi(s[start:i])
+				if err != nil || pos < 1 || pos > 16383 {
+					return nil, fmt.Errorf("invalid tsvector %q: bad position at offset %d", s, start)
+				}
+				p := TSPosition{Position: pos, Weight: 'D'}
+				if i < len(s) && strings.IndexByte("ABCDabcd", s[i]) >= 0 {
+					p.Weight = s[i] &^ 0x20
+					i++
+				}
+				lexeme.Positions = append(lexeme.Positions, p)
+				if i == len(s) || s[i] != ',' {
+					break
+				}
+			}
+		}
+		if i < len(s) && !isArraySpace(s[i]) {
+			return nil, fmt.Errorf("invalid tsvector %q: unexpected %q", s, s[i])
+		}
+		v = append(v, lexeme)
+	}
+}
+
+// Words returns the lexemes without their positions.
+func (v TSVector) Words() []string {
+	words := make([]string, len(v))
+	for i, l := range v {
+		words[i] = l.Word
+	}
+	return words
+}
+
+// Lexeme returns the lexeme for word; ok is false when v does not contain it.
+func (v TSVector) Lexeme(word string) (lexeme TSLexeme, ok bool) {
+	for _, l := range v {
+		if l.Word == word {
+			return l, true
+		}
+	}
+	return TSLexeme{}, false
+}
+
+// String returns the tsvector text format, with every word quoted and the default weight D
+// left out.
+func (v TSVector) String() string {
+	var b strings.Builder
+	for i, l := range v {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		writeTSWord(&b, l.Word)
+		for j, p := range l.Positions {
+			if j == 0 {
+				b.WriteByte(':')
+			} else {
+				b.WriteByte(',')
+			}
+			b.WriteString(strconv.Itoa(p.Position))
+			if p.Weight != 0 && p.Weight != 'D' {
+				b.WriteByte(p.Weight)
+			}
+		}
+	}
+	return b.String()
+}
+
+func writeTSWord(b *strings.Builder, word string) {
+	b.WriteByte('\'')
+	for i := 0; i < len(word); i++ {
+		switch word[i] {
+		case '\'':
+			b.WriteByte('\'')
+		case '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteByte(word[i])
+	}
+	b.WriteByte('\'')
+}
+
+func (v *TSVector) Scan(src interface{}) error {
+	var err error
+	switch s := src.(type) {
+	case nil:
+		*v = nil
+	case []byte:
+		*v, err = ParseTSVector(string(s))
+	case string:
+		*v, err = ParseTSVector(s)
+	default:
+		return fmt.Errorf("cannot scan type %T into TSVector", src)
+	}
+	return err
+}
+
+func (v TSVector) Value() (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return v.String(), nil
+}
+
+func (TSVector) GormDataType() string {
+	return "tsvector"
+}
+
+func (TSVector) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "tsvector"
+	}
+	return ""
+}
+
+// TSTerm returns a query matching the lexeme word. The word is quoted and used as is, so it
+// should already be normalized; to_tsquery normalizes it when the query is run through
+// ToTSQuery.
+func TSTerm(word string) TSQuery {
+	var b strings.Builder
+	writeTSWord(&b, word)
+	return TSQuery(b.String())
+}
+
+// And matches documents matching both q and other, as q & other.
+func (q TSQuery) And(other TSQuery) TSQuery {
+	return q.group() + " & " + other.group()
+}
+
+// Or matches documents matching q or other, as q | other.
+func (q TSQuery) Or(other TSQuery) TSQuery {
+	return q.group() + " | " + other.group()
+}
+
+// Not matches documents not matching q, as !q.
+func (q TSQuery) Not() TSQuery {
+	return "!" + q.group()
+}
+
+// FollowedBy matches documents where other comes right after q, as q <-> other.
+func (q TSQuery) FollowedBy(other TSQuery) TSQuery {
+	return q.group() + " <-> " + other.group()
+}
+
+// Prefix makes the term q match every lexeme starting with it, as 'word':*. It panics when q
+// is not a single term.
+func (q TSQuery) Prefix() TSQuery {
+	if !q.isTerm() || strings.HasSuffix(string(q), ":*") {
+		panic(fmt.Sprintf("pgtypes: Prefix of %q, which is not a single term", q))
+	}
+	return q + ":*"
+}
+
+// group parenthesizes q unless it is a single term.
+func (q TSQuery) group() TSQuery {
+	if q.isTerm() {
+		return q
+	}
+	return "( " + q + " )"
+}
+
+// isTerm reports whether q is a quoted word, as written by TSTerm, optionally marked as a prefix.
+func (q TSQuery) isTerm() bool {
+	if len(q) < 2 || q[0] != '\'' {
+		return false
+	}
+	for i := 1; i < len(q); i++ {
+		switch q[i] {
+		case '\\':
+			i++
+		case '\'':
+			if i+1 < len(q) && q[i+1] == '\'' {
+				i++
+			} else {
+				return i == len(q)-1 || string(q[i+1:]) == ":*"
+			}
+		}
+	}
+	return false
+}
+
+func (q TSQuery) String() string {
+	return string(q)
+}
+
+func (q *TSQuery) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		*q = ""
+	case []byte:
+		*q = TSQuery(s)
+	case string:
+		*q = TSQuery(s)
+	default:
+		return fmt.Errorf("cannot scan type %T into TSQuery", src)
+	}
+	return nil
+}
+
+func (q TSQuery) Value() (driver.Value, error) {
+	return string(q), nil
+}
+
+func (TSQuery) GormDataType() string {
+	return "tsquery"
+}
+
+func (TSQuery) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "tsquery"
+	}
+	return ""
+}
+
+// TSMatch is column @@ query, true when the tsvector column matches query:
+// q.Document.Where(pgtypes.TSMatch(q.Document.Body, pgtypes.WebSearchToTSQuery("english", text))).
+func TSMatch(column, query field.Expr) field.Bool {
+	return field.Bool(field.NewUnsafeFieldRaw("? @@ ?", column, query))
+}
+
+// ToTSQuery is to_tsquery(config, query), which normalizes the words of query with the text
+// search configuration config, or with default_text_search_config when config is empty.
+func ToTSQuery(config string, query TSQuery) field.Field {
+	return tsQueryFunc("to_tsquery", config, string(query))
+}
+
+// PlainToTSQuery is plainto_tsquery(config, text), matching documents containing every word of
+// text.
+func PlainToTSQuery(config, text string) field.Field {
+	return tsQueryFunc("plainto_tsquery", config, text)
+}
+
+// WebSearchToTSQuery is websearch_to_tsquery(config, text), which parses text the way web
+// search engines do: "quoted phrases", or, and -excluded words.
+func WebSearchToTSQuery(config, text string) field.Field {
+	return tsQueryFunc("websearch_to_tsquery", config, text)
+}
+
+func tsQueryFunc(name, config, text string) field.Field {
+	if config == "" {
+		return field.NewUnsafeFieldRaw(name+"(?)", text)
+	}
+	return field.NewUnsafeFieldRaw(name+"(?::regconfig, ?)", config, text)
+}
+
+// TSRank is ts_rank(column, query), for ordering matches by relevance:
+// Order(pgtypes.TSRank(column, query).Desc()).
+func TSRank(column, query field.Expr) field.Float64 {
+	return field.Float64(field.NewUnsafeFieldRaw("ts_rank(?, ?)", column, query))
+}
@@ -0,0 +1,79 @@
+package pgtypes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestTSVector(t *testing.T) {
+	var v TSVector
+	if err := v.Scan([]byte(`'a':1A 'cat':2,5b fat 'it''s':3 'back\\slash'`)); err != nil {
+		t.Fatal(err)
+	}
+	want := TSVector{
+		{Word: "a", Positions: []TSPosition{{1, 'A'}}},
+		{Word: "cat", Positions: []TSPosition{{2, 'D'}, {5, 'B'}}},
+		{Word: "fat"},
+		{Word: "it's", Positions: []TSPosition{{3, 'D'}}},
+		{Word: `back\slash`},
+	}
+	if !reflect.DeepEqual(v, want) {
+		t.Fatalf("scan: %+v", v)
+	}
+	if got, _ := v.Value(); got != `'a':1A 'cat':2,5B 'fat' 'it''s':3 'back\\slash'` {
+		t.Fatalf("value: %v", got)
+	}
+	if l, ok := v.Lexeme("cat"); !ok || len(l.Positions) != 2 || len(v.Words()) != 5 {
+		t.Fatalf("lexeme: %+v", l)
+	}
+	for _, bad := range []string{`'open`, `a:0`, `a:16384`, `a:1X`, `a:`} {
+		if _, err := ParseTSVector(bad); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+	var a TSVectorArray
+	if err := a.Scan(`{"'a':1 'b':2",""}`); err != nil || len(a) != 2 || a[0][1].Word != "b" || len(a[1]) != 0 {
+		t.Fatalf("tsvector[]: %v (%v)", a, err)
+	}
+}
+
+func TestTSQuery(t *testing.T) {
+	q := TSTerm("fat").And(TSTerm("ra").Prefix().Or(TSTerm("it's"))).And(TSTerm("dog").Not())
+	if q != `( 'fat' & ( 'ra':* | 'it''s' ) ) & ( !'dog' )` {
+		t.Fatalf("query: %s", q)
+	}
+	if q := TSTerm("fat").FollowedBy(TSTerm("cat")); q != `'fat' <-> 'cat'` {
+		t.Fatalf("followed by: %s", q)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected Prefix of a compound query to panic")
+		}
+	}()
+	q.Prefix()
+}
+
+func TestTSExprs(t *testing.T) {
+	// Any dialect builds the functions; SQLite only changes the identifier quoting.
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	column := field.NewField("documents", "body")
+	for want, e := range map[string]field.Expr{
+		"`documents`.`body` @@ websearch_to_tsquery(?::regconfig, ?)":   TSMatch(column, WebSearchToTSQuery("english", "fat rat")),
+		"`documents`.`body` @@ plainto_tsquery(?)":                      TSMatch(column, PlainToTSQuery("", "fat rat")),
+		"ts_rank(`documents`.`body`, to_tsquery(?::regconfig, ?)) DESC": TSRank(column, ToTSQuery("english", "fat & rat")).Desc(),
+	} {
+		stmt := &gorm.Statement{DB: db, Clauses: map[string]clause.Clause{}}
+		e.Build(stmt)
+		if stmt.SQL.String() != want || stmt.Vars[len(stmt.Vars)-1] == "" {
+			t.Errorf("got %s %v, want %s", stmt.SQL.String(), stmt.Vars, want)
+		}
+	}
+}
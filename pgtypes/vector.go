@@ -0,0 +1,209 @@
+package pgtypes
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Vector is a value of the pgvector extension's vector type, such as [1,2.5,3]. A nil Vector is
+// stored as NULL. Columns declared vector(n) are generated with the vector serializer and a size
+// tag, which make Scan and Value fail for vectors without n dimensions.
+type Vector []float32
+
+// VectorArray is a PostgreSQL vector[].
+type VectorArray = Array[Vector]
+
+// ParseVector parses the vector text format, a bracketed list of numbers.
+func ParseVector(s string) (Vector, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
+		return nil, fmt.Errorf("invalid vector %q", s)
+	}
+	inner := strings.TrimSpace(s[1 : len(s)-1])
+	if inner == "" {
+		return Vector{}, nil
+	}
+	parts := strings.Split(inner, ",")
+	v := make(Vector, len(parts))
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vector %q: %w", s, err)
+		}
+		v[i] = float32(f)
+	}
+	return v, nil
+}
+
+// Dim returns the number of dimensions.
+func (v Vector) Dim() int {
+	return len(v)
+}
+
+// CheckDim fails unless v has dim dimensions. A dim of 0 or less accepts any vector.
+func (v Vector) CheckDim(dim int) error {
+	if dim > 0 && len(v) != dim {
+		return fmt.Errorf("expected %d dimensions, not %d", dim, len(v))
+	}
+	return nil
+}
+
+// L2Distance returns the Euclidean distance to other, as pgvector's <->. It is NaN when the
+// vectors have different dimensions, where pgvector reports an error.
+func (v Vector) L2Distance(other Vector) float64 {
+	if len(v) != len(other) {
+		return math.NaN()
+	}
+	var sum float64
+	for i := range v {
+		d := float64(v[i]) - float64(other[i])
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
+
+// InnerProduct returns the inner product with other; pgvector's <#> is its negation. It is NaN
+// when the vectors have different dimensions.
+func (v Vector) InnerProduct(other Vector) float64 {
+	if len(v) != len(other) {
+		return math.NaN()
+	}
+	var sum float64
+	for i := range v {
+		sum += float64(v[i]) * float64(other[i])
+	}
+	return sum
+}
+
+// CosineDistance returns 1 minus the cosine similarity with other, as pgvector's <=>. It is NaN
+// when either vector is zero or the vectors have different dimensions.
+func (v Vector) CosineDistance(other Vector) float64 {
+	return 1 - v.InnerProduct(other)/math.Sqrt(v.InnerProduct(v)*other.InnerProduct(other))
+}
+
+// String returns the vector text format.
+func (v Vector) String() string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, f := range v {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(f), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+func (v *Vector) Scan(src interface{}) error {
+	var err error
+	switch t := src.(type) {
+	case nil:
+		*v = nil
+	case []byte:
+		*v, err = ParseVector(string(t))
+	case string:
+		*v, err = ParseVector(t)
+	default:
+		return fmt.Errorf("cannot scan type %T into Vector", src)
+	}
+	return err
+}
+
+func (v Vector) Value() (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return v.String(), nil
+}
+
+func (Vector) GormDataType() string {
+	return "vector"
+}
+
+// GormDBDataType returns vector(n) for a field with a size tag.
+func (Vector) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() != "postgres" {
+		return ""
+	}
+	if field != nil && field.Size > 0 {
+		return fmt.Sprintf("vector(%d)", field.Size)
+	}
+	return "vector"
+}
+
+// VectorSerializer is the "vector" serializer: it scans and writes Vector and *Vector fields,
+// checking them against the dimensions in the field's size tag.
+type VectorSerializer struct{}
+
+func init() {
+	schema.RegisterSerializer("vector", VectorSerializer{})
+}
+
+func (VectorSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	fieldValue := reflect.New(field.FieldType)
+	if dbValue != nil {
+		var v Vector
+		if err := v.Scan(dbValue); err != nil {
+			return err
+		}
+		if err := v.CheckDim(field.Size); err != nil {
+			return fmt.Errorf("cannot scan %s: %w", field.Name, err)
+		}
+		target := fieldValue.Elem()
+		if target.Kind() == reflect.Pointer {
+			target.Set(reflect.New(target.Type().Elem()))
+			target = target.Elem()
+		}
+		target.Set(reflect.ValueOf(v))
+	}
+	return field.Set(ctx, dst, fieldValue.Elem().Interface())
+}
+
+func (VectorSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	var v Vector
+	switch t := fieldValue.(type) {
+	case Vector:
+		v = t
+	case *Vector:
+		if t == nil {
+			return nil, nil
+		}
+		v = *t
+	default:
+		return nil, fmt.Errorf("cannot use the vector serializer for %s of type %T", field.Name, fieldValue)
+	}
+	if v == nil {
+		return nil, nil
+	}
+	if err := v.CheckDim(field.Size); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", field.Name, err)
+	}
+	return v.Value()
+}
+
+// L2Distance is column <-> v, the Euclidean distance, for ordering and filtering queries of the
+// generated query package: q.Item.Order(pgtypes.L2Distance(q.Item.Embedding, v)).Limit(5).
+func L2Distance(column field.Expr, v Vector) field.Float64 {
+	return field.Float64(field.NewUnsafeFieldRaw("? <-> ?", column, v))
+}
+
+// NegativeInnerProduct is column <#> v, the negated inner product, so that ascending order
+// puts the largest inner products first.
+func NegativeInnerProduct(column field.Expr, v Vector) field.Float64 {
+	return field.Float64(field.NewUnsafeFieldRaw("? <#> ?", column, v))
+}
+
+// CosineDistance is column <=> v, the cosine distance.
+func CosineDistance(column field.Expr, v Vector) field.Float64 {
+	return field.Float64(field.NewUnsafeFieldRaw("? <=> ?", column, v))
+}
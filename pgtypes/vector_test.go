@@ -0,0 +1,103 @@
+package pgtypes
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+func TestVector(t *testing.T) {
+	var v Vector
+	if err := v.Scan([]byte("[1, 2.5,-3e-2]")); err != nil || v.Dim() != 3 || v[1] != 2.5 {
+		t.Fatalf("scan: %v (%v)", v, err)
+	}
+	if s, _ := v.Value(); s != "[1,2.5,-0.03]" {
+		t.Fatalf("value: %v", s)
+	}
+	if b, _ := json.Marshal(v); string(b) != "[1,2.5,-0.03]" {
+		t.Fatalf("json: %s", b)
+	}
+	if err := v.CheckDim(3); err != nil || v.CheckDim(4) == nil {
+		t.Fatalf("CheckDim: %v", err)
+	}
+	for _, bad := range []string{"1,2", "[1,,2]", "[a]"} {
+		if _, err := ParseVector(bad); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+	a, b := Vector{3, 0}, Vector{0, 4}
+	if a.L2Distance(b) != 5 || a.InnerProduct(Vector{2, 1}) != 6 || a.CosineDistance(b) != 1 || math.Abs(a.CosineDistance(Vector{1, 0})) > 1e-9 {
+		t.Fatal("unexpected distances")
+	}
+	if long := (Vector{1, 2, 3}); !math.IsNaN(a.L2Distance(long)) || !math.IsNaN(long.InnerProduct(a)) || !math.IsNaN(a.CosineDistance(long)) {
+		t.Fatal("expected NaN for vectors of different dimensions")
+	}
+	if v, _ := (Vector(nil)).Value(); v != nil {
+		t.Fatalf("nil value: %v", v)
+	}
+}
+
+type vectorItem struct {
+	Embedding Vector  `gorm:"size:3;serializer:vector"`
+	Optional  *Vector `gorm:"size:2;serializer:vector"`
+}
+
+func TestVectorSerializer(t *testing.T) {
+	s, err := schema.Parse(&vectorItem{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	item := vectorItem{}
+	dst := reflect.ValueOf(&item).Elem()
+	embedding, optional := s.LookUpField("Embedding"), s.LookUpField("Optional")
+	if err := embedding.Serializer.Scan(ctx, embedding, dst, "[1,2,3]"); err != nil || len(item.Embedding) != 3 {
+		t.Fatalf("scan: %v (%v)", item.Embedding, err)
+	}
+	if err := optional.Serializer.Scan(ctx, optional, dst, []byte("[1,2]")); err != nil || item.Optional == nil || (*item.Optional)[1] != 2 {
+		t.Fatalf("scan pointer: %v (%v)", item.Optional, err)
+	}
+	if err := embedding.Serializer.Scan(ctx, embedding, dst, "[1,2]"); err == nil {
+		t.Fatal("expected dimension error on scan")
+	}
+	if v, err := embedding.Serializer.Value(ctx, embedding, dst, Vector{1, 2, 3}); err != nil || v != "[1,2,3]" {
+		t.Fatalf("value: %v (%v)", v, err)
+	}
+	if _, err := optional.Serializer.Value(ctx, optional, dst, &Vector{1}); err == nil || !strings.Contains(err.Error(), "expected 2 dimensions") {
+		t.Fatalf("expected dimension error, got %v", err)
+	}
+	if v, err := optional.Serializer.Value(ctx, optional, dst, (*Vector)(nil)); err != nil || v != nil {
+		t.Fatalf("nil pointer: %v (%v)", v, err)
+	}
+}
+
+func TestVectorDistanceExprs(t *testing.T) {
+	// Any dialect builds the operators; SQLite only changes the identifier quoting.
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	column := field.NewField("items", "embedding")
+	for want, e := range map[string]field.Expr{
+		"`items`.`embedding` <-> ?":     L2Distance(column, Vector{1, 2}),
+		"`items`.`embedding` <#> ?":     NegativeInnerProduct(column, Vector{1, 2}),
+		"`items`.`embedding` <=> ?":     CosineDistance(column, Vector{1, 2}),
+		"`items`.`embedding` <=> ? < ?": CosineDistance(column, Vector{1, 2}).Lt(0.5),
+	} {
+		stmt := &gorm.Statement{DB: db, Clauses: map[string]clause.Clause{}}
+		e.Build(stmt)
+		if stmt.SQL.String() != want || !reflect.DeepEqual(stmt.Vars[0], Vector{1, 2}) {
+			t.Errorf("got %s %v, want %s", stmt.SQL.String(), stmt.Vars, want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package pgtypes
+
+import (
+	"database/sql/driver"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// XML is a PostgreSQL xml value, a document or a content fragment, kept as text. Value rejects
+// text that is not well-formed, before PostgreSQL would.
+type XML string
+
+// XMLArray is a PostgreSQL xml[].
+type XMLArray = Array[XML]
+
+// MarshalXML returns the XML encoding of v, as encoding/xml writes it.
+func MarshalXML(v any) (XML, error) {
+	b, err := xml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return XML(b), nil
+}
+
+// Unmarshal decodes the document into v with encoding/xml.
+func (x XML) Unmarshal(v any) error {
+	return xml.Unmarshal([]byte(x), v)
+}
+
+// Validate reports whether x is well-formed XML content: elements nest and close properly and
+// entities are known. Several top-level elements are allowed, as in PostgreSQL's CONTENT mode.
+func (x XML) Validate() error {
+	d := xml.NewDecoder(strings.NewReader(string(x)))
+	for {
+		if _, err := d.Token(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("invalid XML: %w", err)
+		}
+	}
+}
+
+func (x XML) String() string {
+	return string(x)
+}
+
+func (x *XML) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*x = ""
+	case []byte:
+		*x = XML(v)
+	case string:
+		*x = XML(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into XML", src)
+	}
+	return nil
+}
+
+func (x XML) Value() (driver.Value, error) {
+	if err := x.Validate(); err != nil {
+		return nil, err
+	}
+	return string(x), nil
+}
+
+func (XML) GormDataType() string {
+	return "xml"
+}
+
+func (XML) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "xml"
+	}
+	return ""
+}
@@ -0,0 +1,38 @@
+package pgtypes
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestXML(t *testing.T) {
+	type item struct {
+		XMLName xml.Name `xml:"item"`
+		SKU     string   `xml:"sku,attr"`
+		Name    string   `xml:"name"`
+	}
+	var x XML
+	if err := x.Scan([]byte(`<item sku="A1"><name>Lamp &amp; shade</name></item>`)); err != nil {
+		t.Fatal(err)
+	}
+	var it item
+	if err := x.Unmarshal(&it); err != nil || it.SKU != "A1" || it.Name != "Lamp & shade" {
+		t.Fatalf("unmarshal: %+v (%v)", it, err)
+	}
+	back, err := MarshalXML(it)
+	if err != nil || back != x {
+		t.Fatalf("marshal: %s (%v)", back, err)
+	}
+	if _, err := XML("<a>1</a><b/>text").Value(); err != nil {
+		t.Errorf("content fragment rejected: %v", err)
+	}
+	for _, bad := range []XML{"<a>", "<a></b>", "<a>&nope;</a>"} {
+		if _, err := bad.Value(); err == nil {
+			t.Errorf("expected %s to be rejected", bad)
+		}
+	}
+	var a XMLArray
+	if err := a.Scan(`{"<a x=\"1\"/>",<b/>}`); err != nil || a[0] != `<a x="1"/>` {
+		t.Fatalf("xml[]: %v (%v)", a, err)
+	}
+}
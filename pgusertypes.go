@@ -0,0 +1,163 @@
+package main
+
+import (
+	"database/sql"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+)
+
+// pgUserType is a user-defined PostgreSQL type (enum, composite, ...) for which a Go type is
+// generated into the models package.
+type pgUserType struct {
+	Schema   string
+	Name     string
+	SQLName  string // format_type() of the type: schema-qualified when not on the search path
+	TypeName string // Go type name
+	Used     bool   // referenced by a generated column
+	UsedArr  bool   // referenced as an array by a generated column
+}
+
+func (t *pgUserType) userType() *pgUserType { return t }
+
+type pgUserTypeOf interface{ userType() *pgUserType }
+
+// pgUserTypes holds the user-defined types of one kind, keyed by SQLName.
+type pgUserTypes[T pgUserTypeOf] map[string]T
+
+// lookup finds the type of a column (or of its elements for arrays). ColumnType() carries the
+// format_type() name, which tells apart types of the same name in different schemas. For a
+// domain over the type it names the domain, so the reported base type name is tried next.
+func (types pgUserTypes[T]) lookup(columnType gorm.ColumnType) (T, bool) {
+	if ct, ok := columnType.ColumnType(); ok {
+		if t, ok := types[strings.TrimSuffix(ct, "[]")]; ok {
+			return t, true
+		}
+	}
+	name := strings.TrimSuffix(columnType.DatabaseTypeName(), "[]")
+	var found T
+	n := 0
+	for _, t := range types {
+		if u := t.userType(); u.Name == name || u.SQLName == name {
+			found = t
+			n++
+		}
+	}
+	return found, n == 1 // ambiguous across schemas when n > 1
+}
+
+// register adds a DataTypeMap entry for every type and its array form, marking the types that
+// end up being used. Entries are keyed the way the postgres driver reports the column: the bare
+// type name for scalars and the formatted name followed by [] for arrays. fallback and
+// arrayFallback are returned for columns that cannot be resolved to a single type.
+func (types pgUserTypes[T]) register(dtMaps map[string]func(gorm.ColumnType) string, fallback, arrayFallback string) {
+	for _, t := range types {
+		u := t.userType()
+		dtMaps[u.Name] = func(columnType gorm.ColumnType) string {
+			match, ok := types.lookup(columnType)
+			if !ok {
+				return fallback
+			}
+			match.userType().Used = true
+			return match.userType().TypeName
+		}
+		dtMaps[u.SQLName+"[]"] = func(columnType gorm.ColumnType) string {
+			match, ok := types.lookup(columnType)
+			if !ok {
+				return arrayFallback
+			}
+			match.userType().Used, match.userType().UsedArr = true, true
+			return match.userType().TypeName + "Array"
+		}
+	}
+}
+
+// pgUserTypeNames returns the Go type names of the types and of their array forms, which other
+// generated types of the models package must not reuse.
+func pgUserTypeNames(kinds ...[]*pgUserType) []string {
+	names := []string{}
+	for _, kind := range kinds {
+		for _, t := range kind {
+			names = append(names, t.TypeName, t.TypeName+"Array")
+		}
+	}
+	return names
+}
+
+// namePgUserTypes assigns Go type names derived from the PostgreSQL type names. Types of the same
+// name in several schemas are prefixed with their schema outside public, and a clash with a model
+// struct or an earlier generated type is resolved with the kind's suffix (e.g. Enum).
+func namePgUserTypes(modelStructNames map[string]bool, kinds ...[]*pgUserType) {
+	counts := map[string]int{}
+	for _, kind := range kinds {
+		for _, t := range kind {
+			counts[strcase.ToCamel(t.Name)]++
+		}
+	}
+	suffixes := []string{"Enum", "Type", "Domain"}
+	taken := map[string]bool{}
+	for name := range modelStructNames {
+		taken[name] = true
+	}
+	for i, kind := range kinds {
+		kind = append([]*pgUserType{}, kind...)
+		sort.Slice(kind, func(a, b int) bool { return kind[a].SQLName < kind[b].SQLName })
+		for _, t := range kind {
+			name := strcase.ToCamel(t.Name)
+			if counts[name] > 1 && t.Schema != "public" {
+				name = strcase.ToCamel(t.Schema + "_" + t.Name)
+			}
+			if taken[name] && i < len(suffixes) {
+				name += suffixes[i]
+			}
+			base := name
+			for n := 2; taken[name]; n++ {
+				name = base + strconv.Itoa(n)
+			}
+			taken[name] = true
+			t.TypeName = name
+		}
+	}
+}
+
+// pgColumnType builds the column metadata the DataTypeMap functions expect for a column-like
+// attribute that is not a table column, such as a field of a composite type.
+func pgColumnType(name, udtName, formattedType string) gorm.ColumnType {
+	return &migrator.ColumnType{
+		NameValue:       sql.NullString{String: name, Valid: true},
+		DataTypeValue:   sql.NullString{String: pgDataTypeName(udtName, formattedType), Valid: true},
+		ColumnTypeValue: sql.NullString{String: formattedType, Valid: true},
+		NullableValue:   sql.NullBool{Bool: true, Valid: true},
+		ScanTypeValue:   pgScanType(udtName),
+	}
+}
+
+// pgDataTypeName mirrors the postgres driver's DatabaseTypeName: the udt name, or the formatted
+// type for arrays (e.g. "text[]"), whose udt names start with an underscore.
+func pgDataTypeName(udtName, formattedType string) string {
+	if strings.HasPrefix(udtName, "_") {
+		return formattedType
+	}
+	return udtName
+}
+
+// pgGoType resolves the Go type of a column-like attribute through dtMaps, falling back to the
+// type pgx scans it into, as gen does for table columns.
+func pgGoType(dtMaps map[string]func(gorm.ColumnType) string, columnType gorm.ColumnType) string {
+	if mapping, ok := dtMaps[columnType.DatabaseTypeName()]; ok {
+		return mapping(columnType)
+	}
+	if mapping, ok := dtMaps[pgUnmodifiedTypeName(columnType.DatabaseTypeName())]; ok {
+		return mapping(columnType)
+	}
+	scanType := columnType.ScanType()
+	if scanType == reflect.TypeOf([]byte(nil)) {
+		return "[]byte"
+	}
+	return scanType.String()
+}
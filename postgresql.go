@@ -3,9 +3,13 @@ package main
 import (
 	"bytes"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/dan-sherwin/go-utilities"
@@ -14,6 +18,7 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gen"
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
 func postgresToGorm(cfg ConversionConfig) {
@@ -55,7 +60,11 @@ func postgresToGorm(cfg ConversionConfig) {
 		Password: cfg.DbPassword,
 		SSLMode:  cfg.DbSSLMode,
 	})
-	db, err = gorm.Open(postgres.Open(dsn))
+	dialector := &pgDialector{
+		Dialector:         postgres.Open(dsn).(*postgres.Dialector),
+		materializedViews: map[string]pgTable{},
+	}
+	db, err = gorm.Open(dialector)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
@@ -81,69 +90,148 @@ func postgresToGorm(cfg ConversionConfig) {
 		Mode:              gen.WithoutContext | gen.WithDefaultQuery | gen.WithQueryInterface, // generate mode
 	})
 
-	tables := []string{}
-	err = db.Raw("select table_name from information_schema.tables where table_schema = 'public'").Scan(&tables).Error
+	schemas := cfg.Schemas
+	if len(schemas) == 0 {
+		schemas = []string{"public"}
+	}
+	tables := []pgTable{}
+	err = db.Raw("select table_schema as schema, table_name as name from information_schema.tables where table_schema in ? order by table_schema, table_name", schemas).Scan(&tables).Error
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 
-	materializedViews := []string{}
-	err = db.Raw("select matviewname from pg_matviews where schemaname='public'").Scan(&materializedViews).Error
+	materializedViews := []pgTable{}
+	err = db.Raw("select schemaname as schema, matviewname as name from pg_matviews where schemaname in ? order by schemaname, matviewname", schemas).Scan(&materializedViews).Error
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	filter, err := newTableFilter(cfg.IncludeTables, cfg.ExcludeTables)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
+	tables = filterPgTables(filter, tables)
+	materializedViews = filterPgTables(filter, materializedViews)
+	for _, view := range materializedViews {
+		dialector.materializedViews[view.QualifiedName()] = view
+	}
+	modelNames := pgModelNames(cfg.NamingStrategy, append(append([]pgTable{}, tables...), materializedViews...))
+	tableComments, columnComments := pgComments(db, schemas)
+	tableAnnotations := map[string]annotations{}
+	for table := range modelNames {
+		name := table.QualifiedName()
+		tableComments[name], tableAnnotations[name] = parseAnnotations(tableComments[name], "table "+name, true)
+	}
+	applyModelNameAnnotations(modelNames, tableAnnotations)
 
 	g.WithJSONTagNameStrategy(func(col string) (tag string) { return strcase.ToLowerCamel(col) })
 	g.WithImportPkgPath(cfg.ImportPackagePaths...)
 	dtMaps := pgtypes.DataTypeMap()
+	modelStructNames := map[string]bool{}
+	for _, name := range modelNames {
+		modelStructNames[name] = true
+	}
+	enums := loadPgEnums(db)
+	composites := loadPgComposites(db)
+	domains := loadPgDomains(db)
+	enumTypes := make([]*pgUserType, 0, len(enums))
+	for _, e := range enums {
+		enumTypes = append(enumTypes, &e.pgUserType)
+	}
+	compositeTypes := make([]*pgUserType, 0, len(composites))
+	for _, c := range composites {
+		compositeTypes = append(compositeTypes, &c.pgUserType)
+	}
+	domainTypes := make([]*pgUserType, 0, len(domains))
+	for _, d := range domains {
+		domainTypes = append(domainTypes, &d.pgUserType)
+	}
+	namePgUserTypes(modelStructNames, enumTypes, compositeTypes, domainTypes)
+	arrayUserTypes := map[string]bool{}
+	for _, t := range slices.Concat(enumTypes, compositeTypes) {
+		arrayUserTypes[t.TypeName] = true
+	}
+	nullableArrays := nullableArrayColumns(cfg.NullableArrayColumns)
+	jsonbColumns := jsonbColumnTypes(cfg.JSONBColumnTypes)
+	var inferrer *jsonbInferrer
+	if cfg.InferJSONBTypes {
+		taken := slices.Concat(slices.Collect(maps.Values(modelNames)), pgUserTypeNames(enumTypes, compositeTypes, domainTypes))
+		inferrer = newJSONBInferrer(db, cfg.JSONBSampleRows, taken)
+	}
+	arrayDims := pgArrayDimensions(db, schemas)
+	enums.register(dtMaps, "string", "pgtypes.StringArray")
+	composites.register(dtMaps, "string", "pgtypes.StringArray")
+	registerPgExtensionTypes(dtMaps, loadPgExtensionTypes(db))
 	for k, v := range cfg.TypeMap {
 		dtMaps[k] = func(columnType gorm.ColumnType) string { return v }
 	}
-	dtMaps["text"] = func(columnType gorm.ColumnType) string {
-		if colType, ok := columnType.ColumnType(); ok {
-			if domain, ok := cfg.DomainTypeMap[colType]; ok {
-				return domain
-			}
-		}
-		return "string"
-	}
+	domainResolver := &pgDomainResolver{domains: domains, typeMap: cfg.DomainTypeMap, generate: cfg.GenerateDomainTypes}
+	domainResolver.register(dtMaps)
 	g.WithDataTypeMap(dtMaps)
 	g.UseDB(db)
-	modelsMap := map[string]any{}
-	for _, tableName := range tables {
-		model := g.GenerateModel(tableName)
-		if ef, ok := cfg.ExtraFields[tableName]; ok {
-			for _, ef := range ef {
-				a := gen.FieldNew("", "", nil)
-				f := a(nil)
-				genRelationField(&ef, gen.Field(f))
-				model.Fields = append(model.Fields, f)
+	var fks []foreignKey
+	uniqueKeys := map[string][][]string{}
+	joins := map[string]joinTable{}
+	if cfg.GenerateRelations || cfg.DetectManyToMany {
+		fks = pgForeignKeys(db, schemas)
+		uniqueKeys = pgUniqueKeys(db, schemas)
+		if cfg.DetectManyToMany {
+			columns := map[string][]string{}
+			structNames := map[string]string{}
+			for _, table := range tables {
+				columns[table.QualifiedName()] = tableColumns(db, table.QualifiedName())
+				structNames[table.QualifiedName()] = modelNames[table]
 			}
+			joins = manyToManyJoins(columns, uniqueKeys, fks, cfg.ExtraFields, func(table string) string { return structNames[table] })
 		}
-		if jsonTagOverrides, ok := cfg.JsonTagOverridesByTable[tableName]; ok {
-			for _, f := range model.Fields {
-				if jsonTag, ok := jsonTagOverrides[f.ColumnName]; ok {
-					f.Tag.Set("json", jsonTag)
-				} else if jsonTag, ok := jsonTagOverrides[f.Name]; ok {
-					f.Tag.Set("json", jsonTag)
-				}
-			}
-		}
-		modelsMap[tableName] = model
 	}
 
-	for _, viewName := range materializedViews {
-		tmpViewName := viewName + "_temp"
-		_, _ = sqldb.Query("drop view if exists " + tmpViewName)
-		_, err = sqldb.Query("create view " + tmpViewName + " as select * from " + viewName)
+	modelsMap := map[string]any{}
+	relationModels := map[string]*relationModel{}
+	searchModels := []pgSearchModel{}
+	// Materialized views are generated like tables; their columns come from pg_attribute (see pgDialector).
+	for _, table := range append(tables, materializedViews...) {
+		tableName := table.QualifiedName()
+		if _, ok := joins[tableName]; ok {
+			continue // represented by many2many fields on the tables it joins
+		}
+		// The column types are read once and shared by the steps below.
+		columnTypes, err := db.Migrator().ColumnTypes(tableName)
 		if err != nil {
 			log.Fatal(err.Error())
 		}
-		defer sqldb.Query("drop view " + tmpViewName)
-		modelName := cfg.NamingStrategy.SchemaName(viewName)
-		model := g.GenerateModelAs(tmpViewName, modelName)
-
-		if ef, ok := cfg.ExtraFields[viewName]; ok {
+		addModifiedArrayTypes(columnTypes, dtMaps)
+		model := g.GenerateModelAs(tableName, modelNames[table])
+		model.FileName = table.FileName()
+		comments := map[string]string{}
+		columnAnnotations := map[string]annotations{}
+		for column, comment := range columnComments[tableName] {
+			comments[column], columnAnnotations[column] = parseAnnotations(comment, "column "+tableName+"."+column, false)
+		}
+		var schemas map[string]map[string]any
+		if inferrer != nil {
+			schemas = extractSchemas(model.Fields, comments)
+		}
+		columnDocComments(columnTypes, model.Fields, comments, nil)
+		addDecimalSizeTags(columnTypes, model.Fields, nil)
+		addVectorDimensionTags(model.Fields)
+		applyArrayDimensions(tableName, model.Fields, arrayDims[tableName], arrayUserTypes)
+		applyNullableArrayColumns(tableName, model.Fields, nullableArrays[tableName], arrayUserTypes)
+		if inferrer != nil {
+			inferrer.inferColumns(tableName, model.ModelStructName, model.Fields, schemas, func(column string) bool {
+				_, bound := jsonbColumns[tableName][column]
+				return bound || columnAnnotations[column].GoType != ""
+			})
+		}
+		for _, spec := range append(applyJSONBColumnTypes(tableName, model.Fields, jsonbColumns[tableName]),
+			applyColumnAnnotations(model.Fields, columnAnnotations, tableAnnotations[tableName].ReadOnly)...) {
+			if !slices.Contains(model.ImportPkgPaths, spec) {
+				model.ImportPkgPaths = append(model.ImportPkgPaths, spec)
+			}
+		}
+		if comment := tableDocComment(tableName, tableComments[tableName]); comment != "" {
+			model.TableComment = comment
+		}
+		if ef, ok := cfg.ExtraFields[tableName]; ok {
 			for _, ef := range ef {
 				a := gen.FieldNew("", "", nil)
 				f := a(nil)
@@ -151,9 +239,7 @@ func postgresToGorm(cfg ConversionConfig) {
 				model.Fields = append(model.Fields, f)
 			}
 		}
-		model.FileName = viewName
-		model.TableName = viewName
-		if jsonTagOverrides, ok := cfg.JsonTagOverridesByTable[viewName]; ok {
+		if jsonTagOverrides, ok := cfg.JsonTagOverridesByTable[tableName]; ok {
 			for _, f := range model.Fields {
 				if jsonTag, ok := jsonTagOverrides[f.ColumnName]; ok {
 					f.Tag.Set("json", jsonTag)
@@ -162,7 +248,14 @@ func postgresToGorm(cfg ConversionConfig) {
 				}
 			}
 		}
-		modelsMap[viewName] = model
+		if m, ok := newPgSearchModel(model.FileName, model.ModelStructName, model.QueryStructName, model.Fields); ok {
+			searchModels = append(searchModels, m)
+		}
+		modelsMap[tableName] = model
+		relationModels[tableName] = &relationModel{StructName: model.ModelStructName, Fields: &model.Fields, UniqueKeys: uniqueKeys[tableName]}
+	}
+	if cfg.GenerateRelations || cfg.DetectManyToMany {
+		addForeignKeyRelations(cfg, relationModels, fks, joins)
 	}
 
 	models := []any{}
@@ -171,11 +264,95 @@ func postgresToGorm(cfg ConversionConfig) {
 	}
 	g.ApplyBasic(models...)
 	g.Execute()
+	generatePgSearch(cfg, searchModels)
+	if inferrer != nil {
+		generateJSONBStructs(cfg, inferrer.Structs)
+	}
+	generatePgComposites(cfg, composites, dtMaps)
+	generatePgEnums(cfg, enums)
+	generatePgDomains(cfg, domains)
 	if cfg.GenerateDbInit {
 		generatePostgresDbInit(cfg, g)
 	}
 }
 
+// pgTable identifies a table, view or materialized view within a PostgreSQL schema.
+type pgTable struct {
+	Schema string
+	Name   string
+}
+
+// QualifiedName returns the name used for TableName() and for the per-table config maps.
+// Relations in the public schema keep their bare name; all others are schema-qualified.
+func (t pgTable) QualifiedName() string {
+	if t.Schema == "" || t.Schema == "public" {
+		return t.Name
+	}
+	return t.Schema + "." + t.Name
+}
+
+// FileName returns the base name of the generated model and query files.
+func (t pgTable) FileName() string {
+	return strings.ToLower(strings.ReplaceAll(t.QualifiedName(), ".", "_"))
+}
+
+// filterPgTables keeps the relations allowed by the include/exclude patterns.
+func filterPgTables(filter *tableFilter, tables []pgTable) []pgTable {
+	out := []pgTable{}
+	for _, t := range tables {
+		if filter.Allows(t.QualifiedName(), t.Name) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// applyModelNameAnnotations renames the models whose table comment has a @name annotation,
+// unless another model already has that name.
+func applyModelNameAnnotations(names map[pgTable]string, tableAnnotations map[string]annotations) {
+	taken := map[string]bool{}
+	for _, name := range names {
+		taken[name] = true
+	}
+	tables := make([]pgTable, 0, len(names))
+	for t := range names {
+		tables = append(tables, t)
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].QualifiedName() < tables[j].QualifiedName() })
+	for _, t := range tables {
+		name := tableAnnotations[t.QualifiedName()].Name
+		if name == "" || name == names[t] {
+			continue
+		}
+		if taken[name] {
+			log.Printf("warning: table %s: @name:%s is already the name of another model, keeping %s", t.QualifiedName(), name, names[t])
+			continue
+		}
+		delete(taken, names[t])
+		taken[name] = true
+		names[t] = name
+	}
+}
+
+// pgModelNames assigns a struct name to every relation. When the same name is produced by
+// more than one schema, relations outside public are prefixed with their schema name
+// (billing.users -> BillingUser) so that generated structs never collide.
+func pgModelNames(ns schema.NamingStrategy, tables []pgTable) map[pgTable]string {
+	counts := map[string]int{}
+	for _, t := range tables {
+		counts[ns.SchemaName(t.Name)]++
+	}
+	names := make(map[pgTable]string, len(tables))
+	for _, t := range tables {
+		name := ns.SchemaName(t.Name)
+		if counts[name] > 1 && t.Schema != "public" {
+			name = ns.SchemaName(t.Schema + "_" + t.Name)
+		}
+		names[t] = name
+	}
+	return names
+}
+
 func generatePostgresDbInit(cfg ConversionConfig, g *gen.Generator) {
 	outPath := g.OutPath
 	fullPackageName := filepath.Base(outPath)
@@ -0,0 +1,335 @@
+package main
+
+import (
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/jinzhu/inflection"
+	"gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+// foreignKey is a foreign key constraint between two tables. Table names are the same keys used
+// by the per-table config maps (schema-qualified outside public for PostgreSQL).
+type foreignKey struct {
+	Name       string
+	Table      string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+}
+
+// relationModel is what relation inference needs to know about a generated model.
+type relationModel struct {
+	StructName string
+	Fields     *[]gen.Field
+	UniqueKeys [][]string // column sets covered by the primary key or a unique constraint
+}
+
+// joinTable is a pure join table that is turned into many2many fields instead of a model.
+type joinTable struct {
+	StructName string
+	Keys       [2]foreignKey // in the order their columns appear in the join table's key
+}
+
+// addForeignKeyRelations appends the relation fields inferred from fks and joins to the generated
+// models. Without GenerateRelations only the many2many fields of joins are added.
+func addForeignKeyRelations(cfg ConversionConfig, models map[string]*relationModel, fks []foreignKey, joins map[string]joinTable) {
+	skip := map[string]bool{}
+	for _, t := range cfg.SkipRelationsForTables {
+		skip[t] = true
+	}
+	if !cfg.GenerateRelations {
+		fks = nil
+	}
+	relations := inferRelations(models, fks, joins, cfg.ExtraFields, skip)
+	for table, efs := range relations {
+		m := models[table]
+		for _, ef := range efs {
+			a := gen.FieldNew("", "", nil)
+			f := a(nil)
+			genRelationField(&ef, gen.Field(f))
+			if jsonTag, ok := cfg.JsonTagOverridesByTable[table][ef.StructPropName]; ok {
+				f.Tag.Set("json", jsonTag)
+			}
+			*m.Fields = append(*m.Fields, f)
+		}
+	}
+}
+
+// manyToManyJoins finds the pure join tables among the tables in columns: tables holding exactly two
+// foreign keys to other generated tables whose columns are all of the table's columns and together
+// form its primary (or a unique) key. Join tables with payload columns, or with hand-written
+// ExtraFields, keep their own model. structName gives the struct name a table would be generated as.
+func manyToManyJoins(columns map[string][]string, uniqueKeys map[string][][]string, fks []foreignKey, extraFields map[string][]ExtraField, structName func(table string) string) map[string]joinTable {
+	byTable := map[string][]foreignKey{}
+	for _, fk := range fks {
+		byTable[fk.Table] = append(byTable[fk.Table], fk)
+	}
+	joins := map[string]joinTable{}
+	for table, tfks := range byTable {
+		if len(tfks) != 2 || len(extraFields[table]) > 0 || columns[tfks[0].RefTable] == nil || columns[tfks[1].RefTable] == nil {
+			continue
+		}
+		keyColumns := append(append([]string{}, tfks[0].Columns...), tfks[1].Columns...)
+		covered := map[string]bool{}
+		for _, c := range keyColumns {
+			covered[c] = true
+		}
+		pure := len(covered) == len(keyColumns) && len(covered) == len(columns[table])
+		for _, c := range columns[table] {
+			pure = pure && covered[c]
+		}
+		key := uniqueKeyFor(uniqueKeys[table], keyColumns)
+		if !pure || key == nil {
+			continue
+		}
+		position := func(fk foreignKey) int {
+			for i, c := range key {
+				if c == fk.Columns[0] {
+					return i
+				}
+			}
+			return len(key)
+		}
+		if position(tfks[1]) < position(tfks[0]) {
+			tfks[0], tfks[1] = tfks[1], tfks[0]
+		}
+		joins[table] = joinTable{StructName: structName(table), Keys: [2]foreignKey{tfks[0], tfks[1]}}
+	}
+	return joins
+}
+
+// tableColumns returns the column names of a table as reported by the migrator.
+func tableColumns(db *gorm.DB, table string) []string {
+	columnTypes, err := db.Migrator().ColumnTypes(table)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	columns := make([]string, 0, len(columnTypes))
+	for _, ct := range columnTypes {
+		columns = append(columns, ct.Name())
+	}
+	return columns
+}
+
+// inferRelations derives a BelongsTo field on the referencing (child) model and a HasOne or
+// HasMany field on the referenced (parent) model for every foreign key between generated tables.
+// HasOne is used when the foreign key columns are unique in the child. Foreign keys of the join
+// tables in joins become many2many fields on the two tables they connect instead. Relations
+// already declared by hand in extraFields are not duplicated, and tables in skip receive no
+// generated fields.
+func inferRelations(models map[string]*relationModel, fks []foreignKey, joins map[string]joinTable, extraFields map[string][]ExtraField, skip map[string]bool) map[string][]ExtraField {
+	fks = append([]foreignKey{}, fks...)
+	sort.Slice(fks, func(i, j int) bool {
+		if fks[i].Table != fks[j].Table {
+			return fks[i].Table < fks[j].Table
+		}
+		return fks[i].Name < fks[j].Name
+	})
+	// Several foreign keys between the same pair of tables need their role in the parent-side name.
+	pairs := map[[2]string]int{}
+	for _, fk := range fks {
+		pairs[[2]string{fk.Table, fk.RefTable}]++
+	}
+	taken := map[string]map[string]bool{}
+	for table, m := range models {
+		taken[table] = map[string]bool{}
+		for _, f := range *m.Fields {
+			taken[table][f.Name] = true
+		}
+	}
+
+	out := map[string][]ExtraField{}
+	for _, fk := range fks {
+		child, parent := models[fk.Table], models[fk.RefTable]
+		if _, ok := joins[fk.Table]; ok || child == nil || parent == nil {
+			continue
+		}
+		fkFields := fieldNamesForColumns(*child.Fields, fk.Columns)
+		refFields := fieldNamesForColumns(*parent.Fields, fk.RefColumns)
+		if fkFields == "" || refFields == "" {
+			continue
+		}
+		role := relationRole(fk, parent.StructName)
+
+		if !skip[fk.Table] && !hasManualRelation(extraFields[fk.Table], parent.StructName, fkFields) {
+			name := uniqueFieldName(taken[fk.Table], role, role+parent.StructName)
+			out[fk.Table] = append(out[fk.Table], ExtraField{
+				StructPropName:    name,
+				StructPropType:    "models." + parent.StructName,
+				FkStructPropName:  fkFields,
+				RefStructPropName: refFields,
+				BelongsTo:         true,
+				Pointer:           true,
+			})
+		}
+
+		if !skip[fk.RefTable] && !hasManualRelation(extraFields[fk.RefTable], child.StructName, fkFields) {
+			hasOne := isUniqueKey(child.UniqueKeys, fk.Columns)
+			base := child.StructName
+			if !hasOne {
+				base = inflection.Plural(base)
+			}
+			candidates := []string{base, base + "By" + role}
+			if fk.Table == fk.RefTable || pairs[[2]string{fk.Table, fk.RefTable}] > 1 {
+				candidates = candidates[1:]
+			}
+			out[fk.RefTable] = append(out[fk.RefTable], ExtraField{
+				StructPropName:    uniqueFieldName(taken[fk.RefTable], candidates...),
+				StructPropType:    "models." + child.StructName,
+				FkStructPropName:  fkFields,
+				RefStructPropName: refFields,
+				HasMany:           !hasOne,
+				Pointer:           hasOne,
+			})
+		}
+	}
+
+	joinTables := make([]string, 0, len(joins))
+	for table := range joins {
+		joinTables = append(joinTables, table)
+	}
+	sort.Strings(joinTables)
+	for _, table := range joinTables {
+		join := joins[table]
+		a, b := join.Keys[0], join.Keys[1]
+		sides := [][2]foreignKey{{a, b}, {b, a}}
+		if a.RefTable == b.RefTable {
+			sides = sides[:1] // self-referencing: one field, named after the second key's role
+		}
+		for _, side := range sides {
+			own, other := side[0], side[1]
+			owner, target := models[own.RefTable], models[other.RefTable]
+			if owner == nil || target == nil || skip[own.RefTable] || hasManualJoin(extraFields[own.RefTable], table) {
+				continue
+			}
+			ownKeys := fieldNamesForColumns(*owner.Fields, own.RefColumns)
+			otherKeys := fieldNamesForColumns(*target.Fields, other.RefColumns)
+			if ownKeys == "" || otherKeys == "" {
+				continue
+			}
+			base := inflection.Plural(target.StructName)
+			if a.RefTable == b.RefTable {
+				base = inflection.Plural(relationRole(other, target.StructName))
+			}
+			out[own.RefTable] = append(out[own.RefTable], ExtraField{
+				StructPropName:        uniqueFieldName(taken[own.RefTable], base, base+"Via"+join.StructName),
+				StructPropType:        "models." + target.StructName,
+				FkStructPropName:      ownKeys,
+				RefStructPropName:     otherKeys,
+				Many2Many:             table,
+				JoinFkStructPropName:  joinFieldNames(own.Columns),
+				JoinRefStructPropName: joinFieldNames(other.Columns),
+			})
+		}
+	}
+	return out
+}
+
+// joinFieldNames names the join table columns the way gen would have named their fields, since
+// the join table has no generated struct to take field names from.
+func joinFieldNames(columns []string) string {
+	names := make([]string, 0, len(columns))
+	for _, c := range columns {
+		names = append(names, goFieldName(c))
+	}
+	return strings.Join(names, ",")
+}
+
+func hasManualJoin(efs []ExtraField, joinTable string) bool {
+	for _, ef := range efs {
+		if ef.Many2Many == joinTable {
+			return true
+		}
+	}
+	return false
+}
+
+// relationRole names the part a referenced row plays for the child: author_id -> Author.
+// Composite keys and columns without an _id suffix fall back to the referenced struct name.
+func relationRole(fk foreignKey, parentStructName string) string {
+	if len(fk.Columns) == 1 {
+		col := strings.ToLower(fk.Columns[0])
+		if strings.HasSuffix(col, "_id") && len(col) > len("_id") {
+			return strcase.ToCamel(fk.Columns[0][:len(col)-len("_id")])
+		}
+	}
+	return parentStructName
+}
+
+// fieldNamesForColumns returns the comma-joined struct field names for columns, or "" when a
+// column has no field in the model (e.g. it was removed by a field option).
+func fieldNamesForColumns(fields []gen.Field, columns []string) string {
+	names := make([]string, 0, len(columns))
+	for _, col := range columns {
+		name := ""
+		for _, f := range fields {
+			if f.ColumnName == col {
+				name = f.Name
+				break
+			}
+		}
+		if name == "" {
+			return ""
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ",")
+}
+
+func hasManualRelation(efs []ExtraField, structName, fkFields string) bool {
+	for _, ef := range efs {
+		t := ef.StructPropType
+		if i := strings.LastIndex(t, "."); i != -1 {
+			t = t[i+1:]
+		}
+		if t == structName && ef.FkStructPropName == fkFields {
+			return true
+		}
+	}
+	return false
+}
+
+func isUniqueKey(uniqueKeys [][]string, columns []string) bool {
+	return uniqueKeyFor(uniqueKeys, columns) != nil
+}
+
+// uniqueKeyFor returns the unique key made of exactly columns, in any order.
+func uniqueKeyFor(uniqueKeys [][]string, columns []string) []string {
+	want := append([]string{}, columns...)
+	sort.Strings(want)
+	for _, key := range uniqueKeys {
+		got := append([]string{}, key...)
+		sort.Strings(got)
+		if strings.Join(got, ",") == strings.Join(want, ",") {
+			return key
+		}
+	}
+	return nil
+}
+
+// uniqueFieldName returns the first candidate not yet used in the model, numbering the last
+// candidate when all are taken, and records the result as used.
+func uniqueFieldName(taken map[string]bool, candidates ...string) string {
+	name := ""
+	for _, c := range candidates {
+		if !taken[c] {
+			name = c
+			break
+		}
+	}
+	if name == "" {
+		last := candidates[len(candidates)-1]
+		for i := 2; name == ""; i++ {
+			if c := last + strconv.Itoa(i); !taken[c] {
+				name = c
+			}
+		}
+	}
+	taken[name] = true
+	return name
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iancoleman/strcase"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+func testRelationModel(structName string, columns map[string]string, uniqueKeys ...[]string) *relationModel {
+	fields := []gen.Field{}
+	for col, name := range columns {
+		f := gen.FieldNew("", "", nil)(nil)
+		f.Name, f.ColumnName = name, col
+		fields = append(fields, f)
+	}
+	return &relationModel{StructName: structName, Fields: &fields, UniqueKeys: uniqueKeys}
+}
+
+func TestInferRelations(t *testing.T) {
+	models := map[string]*relationModel{
+		"users":     testRelationModel("User", map[string]string{"id": "ID"}, []string{"id"}),
+		"posts":     testRelationModel("Post", map[string]string{"id": "ID", "author_id": "AuthorID", "editor_id": "EditorID"}, []string{"id"}),
+		"profiles":  testRelationModel("Profile", map[string]string{"id": "ID", "user_id": "UserID"}, []string{"id"}, []string{"user_id"}),
+		"employees": testRelationModel("Employee", map[string]string{"id": "ID", "manager_id": "ManagerID"}, []string{"id"}),
+		"orders":    testRelationModel("Order", map[string]string{"region": "Region", "num": "Num"}, []string{"region", "num"}),
+		"lines":     testRelationModel("Line", map[string]string{"id": "ID", "region": "Region", "num": "Num"}, []string{"id"}),
+	}
+	fks := []foreignKey{
+		{Name: "posts_author_fk", Table: "posts", Columns: []string{"author_id"}, RefTable: "users", RefColumns: []string{"id"}},
+		{Name: "posts_editor_fk", Table: "posts", Columns: []string{"editor_id"}, RefTable: "users", RefColumns: []string{"id"}},
+		{Name: "profiles_user_fk", Table: "profiles", Columns: []string{"user_id"}, RefTable: "users", RefColumns: []string{"id"}},
+		{Name: "employees_manager_fk", Table: "employees", Columns: []string{"manager_id"}, RefTable: "employees", RefColumns: []string{"id"}},
+		{Name: "lines_order_fk", Table: "lines", Columns: []string{"region", "num"}, RefTable: "orders", RefColumns: []string{"region", "num"}},
+		{Name: "dangling_fk", Table: "posts", Columns: []string{"author_id"}, RefTable: "not_generated", RefColumns: []string{"id"}},
+	}
+	manual := map[string][]ExtraField{
+		"users": {{StructPropName: "Profile", StructPropType: "models.Profile", FkStructPropName: "UserID", RefStructPropName: "ID"}},
+	}
+	got := inferRelations(models, fks, nil, manual, map[string]bool{"lines": true})
+
+	type rel struct {
+		name, typ, fk, ref string
+		kind               field.RelationshipType
+	}
+	kind := func(ef ExtraField) field.RelationshipType {
+		switch {
+		case ef.BelongsTo:
+			return field.BelongsTo
+		case ef.HasMany:
+			return field.HasMany
+		}
+		return field.HasOne
+	}
+	want := map[string][]rel{
+		"posts": {
+			{"Author", "models.User", "AuthorID", "ID", field.BelongsTo},
+			{"Editor", "models.User", "EditorID", "ID", field.BelongsTo},
+		},
+		"users": {
+			{"PostsByAuthor", "models.Post", "AuthorID", "ID", field.HasMany},
+			{"PostsByEditor", "models.Post", "EditorID", "ID", field.HasMany},
+		},
+		"profiles":  {{"User", "models.User", "UserID", "ID", field.BelongsTo}},
+		"employees": {{"Manager", "models.Employee", "ManagerID", "ID", field.BelongsTo}, {"EmployeesByManager", "models.Employee", "ManagerID", "ID", field.HasMany}},
+		"orders":    {{"Lines", "models.Line", "Region,Num", "Region,Num", field.HasMany}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("relations for %d tables, want %d: %+v", len(got), len(want), got)
+	}
+	for table, rels := range want {
+		if len(got[table]) != len(rels) {
+			t.Fatalf("%s: got %+v, want %+v", table, got[table], rels)
+		}
+		for i, r := range rels {
+			ef := got[table][i]
+			g := rel{ef.StructPropName, ef.StructPropType, ef.FkStructPropName, ef.RefStructPropName, kind(ef)}
+			if g != r {
+				t.Errorf("%s[%d]: got %+v, want %+v", table, i, g, r)
+			}
+		}
+	}
+}
+
+func TestInferRelationsHasOne(t *testing.T) {
+	models := map[string]*relationModel{
+		"users":    testRelationModel("User", map[string]string{"id": "ID"}, []string{"id"}),
+		"profiles": testRelationModel("Profile", map[string]string{"id": "ID", "user_id": "UserID"}, []string{"id"}, []string{"user_id"}),
+	}
+	fks := []foreignKey{{Name: "fk", Table: "profiles", Columns: []string{"user_id"}, RefTable: "users", RefColumns: []string{"id"}}}
+	got := inferRelations(models, fks, nil, nil, nil)["users"]
+	if len(got) != 1 || got[0].StructPropName != "Profile" || got[0].HasMany || !got[0].Pointer {
+		t.Fatalf("expected a HasOne *Profile, got %+v", got)
+	}
+}
+
+func TestManyToManyJoins(t *testing.T) {
+	columns := map[string][]string{
+		"users":       {"id", "name"},
+		"roles":       {"id", "name"},
+		"user_roles":  {"role_id", "user_id"},
+		"memberships": {"user_id", "role_id", "since"},
+		"friendships": {"user_id", "friend_id"},
+	}
+	uniqueKeys := map[string][][]string{
+		"user_roles":  {{"user_id", "role_id"}},
+		"memberships": {{"user_id", "role_id"}},
+		"friendships": {{"user_id", "friend_id"}},
+	}
+	fk := func(table, col, ref string) foreignKey {
+		return foreignKey{Name: table + "_" + col, Table: table, Columns: []string{col}, RefTable: ref, RefColumns: []string{"id"}}
+	}
+	fks := []foreignKey{
+		fk("user_roles", "role_id", "roles"), fk("user_roles", "user_id", "users"),
+		fk("memberships", "user_id", "users"), fk("memberships", "role_id", "roles"),
+		fk("friendships", "friend_id", "users"), fk("friendships", "user_id", "users"),
+	}
+	joins := manyToManyJoins(columns, uniqueKeys, fks, nil, strcase.ToCamel)
+	if len(joins) != 2 {
+		t.Fatalf("expected user_roles and friendships as join tables, got %+v", joins)
+	}
+	if j := joins["user_roles"]; j.StructName != "UserRoles" || j.Keys[0].Columns[0] != "user_id" || j.Keys[1].Columns[0] != "role_id" {
+		t.Errorf("user_roles keys not in primary key order: %+v", j)
+	}
+
+	models := map[string]*relationModel{
+		"users": testRelationModel("User", map[string]string{"id": "ID"}, []string{"id"}),
+		"roles": testRelationModel("Role", map[string]string{"id": "ID"}, []string{"id"}),
+	}
+	got := inferRelations(models, nil, joins, nil, nil)
+	want := map[string][]string{
+		"users": {"Friends:friendships:UserID:FriendID", "Roles:user_roles:UserID:RoleID"},
+		"roles": {"Users:user_roles:RoleID:UserID"},
+	}
+	for table, rels := range want {
+		if len(got[table]) != len(rels) {
+			t.Fatalf("%s: got %+v, want %v", table, got[table], rels)
+		}
+		for i, r := range rels {
+			ef := got[table][i]
+			if g := strings.Join([]string{ef.StructPropName, ef.Many2Many, ef.JoinFkStructPropName, ef.JoinRefStructPropName}, ":"); g != r {
+				t.Errorf("%s[%d]: got %s, want %s", table, i, g, r)
+			}
+		}
+	}
+
+	if joins := manyToManyJoins(columns, uniqueKeys, fks, map[string][]ExtraField{"user_roles": {{StructPropName: "X"}}}, strcase.ToCamel); len(joins) != 1 {
+		t.Errorf("join table with ExtraFields should keep its model, got %+v", joins)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"text/template"
 
 	"github.com/dan-sherwin/gormdb2struct/sqlitetype"
@@ -49,14 +51,81 @@ func sqliteToGorm(cfg ConversionConfig) {
 		dtMaps[k] = func(columnType gorm.ColumnType) string { return v }
 	}
 	g.WithDataTypeMap(dtMaps)
-	g.WithImportPkgPath("gorm.io/datatypes")
+	g.WithImportPkgPath("gorm.io/datatypes", "github.com/dan-sherwin/gormdb2struct/pgtypes")
 	g.UseDB(db)
 
+	filter, err := newTableFilter(cfg.IncludeTables, cfg.ExcludeTables)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	tableNames := []string{}
+	for _, tableName := range sqlitetype.TableNames(db) {
+		if filter.Allows(tableName) {
+			tableNames = append(tableNames, tableName)
+		}
+	}
+
+	var fks []foreignKey
+	uniqueKeys := map[string][][]string{}
+	joins := map[string]joinTable{}
+	if cfg.GenerateRelations || cfg.DetectManyToMany {
+		columns := map[string][]string{}
+		for _, tableName := range tableNames {
+			columns[tableName] = tableColumns(db, tableName)
+			uniqueKeys[tableName] = sqlitetype.UniqueKeys(db, tableName)
+			for _, fk := range sqlitetype.ForeignKeys(db, tableName) {
+				fks = append(fks, foreignKey{
+					Name:       tableName + "(" + strings.Join(fk.Columns, ",") + ")", // SQLite foreign keys are unnamed
+					Table:      tableName,
+					Columns:    fk.Columns,
+					RefTable:   fk.RefTable,
+					RefColumns: fk.RefColumns,
+				})
+			}
+		}
+		if cfg.DetectManyToMany {
+			joins = manyToManyJoins(columns, uniqueKeys, fks, cfg.ExtraFields, db.NamingStrategy.SchemaName)
+		}
+	}
+
+	jsonbColumns := jsonbColumnTypes(cfg.JSONBColumnTypes)
 	// Build models to allow extraFields and jsonTagOverrides like Postgres path
 	modelsMap := map[string]any{}
 	modelStructNames := []string{}
-	for _, tableName := range sqlitetype.TableNames(db) {
+	relationModels := map[string]*relationModel{}
+	for _, tableName := range tableNames {
+		if _, ok := joins[tableName]; ok {
+			continue // represented by many2many fields on the tables it joins
+		}
+		columns := sqlitetype.Columns(db, tableName)
+		sqlitetype.AddSizedTypes(dtMaps, columns)
 		model := g.GenerateModel(tableName)
+		tableComment, columnComments := sqlitetype.Comments(db, tableName)
+		summaries, declared := map[string]string{}, map[string]string{}
+		for _, c := range columns {
+			declared[c.Name] = c.Type
+			def := ""
+			if c.Default != nil {
+				def = *c.Default
+			}
+			// A primary key column cannot hold NULL (INTEGER PRIMARY KEY is the rowid).
+			summaries[c.Name] = formatColumnSummary(c.Type, !c.NotNull && !c.PrimaryKey, true, def)
+		}
+		columnTypes, err := db.Migrator().ColumnTypes(tableName)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		columnDocComments(columnTypes, model.Fields, columnComments, summaries)
+		addDecimalSizeTags(columnTypes, model.Fields, declared)
+		for _, spec := range applyJSONBColumnTypes(tableName, model.Fields, jsonbColumns[tableName]) {
+			if !slices.Contains(model.ImportPkgPaths, spec) {
+				model.ImportPkgPaths = append(model.ImportPkgPaths, spec)
+			}
+		}
+		if comment := tableDocComment(tableName, tableComment); comment != "" {
+			model.TableComment = comment
+		}
 		if ef, ok := cfg.ExtraFields[tableName]; ok {
 			for _, ef := range ef {
 				a := gen.FieldNew("", "", nil)
@@ -76,6 +145,10 @@ func sqliteToGorm(cfg ConversionConfig) {
 		}
 		modelsMap[tableName] = model
 		modelStructNames = append(modelStructNames, model.ModelStructName)
+		relationModels[tableName] = &relationModel{StructName: model.ModelStructName, Fields: &model.Fields, UniqueKeys: uniqueKeys[tableName]}
+	}
+	if cfg.GenerateRelations || cfg.DetectManyToMany {
+		addForeignKeyRelations(cfg, relationModels, fks, joins)
 	}
 
 	models := []any{}
@@ -0,0 +1,204 @@
+package sqlitetype
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Comments returns the -- comments written in the CREATE TABLE statement of table, which SQLite
+// keeps verbatim in sqlite_master. A comment between CREATE TABLE and the opening parenthesis,
+// or on the same line as the parenthesis, describes the table. A comment at the end of a column
+// definition, or on the lines right above it, describes that column.
+func Comments(db *gorm.DB, table string) (tableComment string, columnComments map[string]string) {
+	var ddl string
+	err := db.Raw("SELECT sql FROM sqlite_master WHERE type='table' AND name = ?", table).Scan(&ddl).Error
+	if err != nil {
+		panic(err)
+	}
+	return parseDDLComments(ddl)
+}
+
+// Column is a column of a SQLite table as reported by PRAGMA table_info.
+type Column struct {
+	Name       string
+	Type       string
+	NotNull    bool
+	Default    *string
+	PrimaryKey bool
+}
+
+// Columns returns the columns of table in declaration order. Unlike the migrator's column types,
+// which come from parsing the CREATE TABLE statement, this also works for statements the driver
+// cannot parse (e.g. CREATE TABLE IF NOT EXISTS).
+func Columns(db *gorm.DB, table string) []Column {
+	rows := []struct {
+		Name      string
+		Type      string
+		NotNull   bool
+		DfltValue *string
+		Pk        int
+	}{}
+	err := db.Raw(`SELECT name, type, "notnull" AS not_null, dflt_value, pk FROM pragma_table_info(?) ORDER BY cid`, table).Scan(&rows).Error
+	if err != nil {
+		panic(err)
+	}
+	columns := make([]Column, 0, len(rows))
+	for _, r := range rows {
+		columns = append(columns, Column{Name: r.Name, Type: r.Type, NotNull: r.NotNull, Default: r.DfltValue, PrimaryKey: r.Pk > 0})
+	}
+	return columns
+}
+
+type ddlDefinition struct {
+	text     strings.Builder
+	lastLine int // line of the definition's last token, -1 while empty
+	comments []string
+}
+
+func parseDDLComments(ddl string) (tableComment string, columnComments map[string]string) {
+	var tableLines, pending []string
+	var defs []*ddlDefinition
+	cur := &ddlDefinition{lastLine: -1}
+	line, depth, parenLine := 0, 0, -1
+	content := func(c byte) {
+		if len(pending) > 0 && cur.lastLine == -1 {
+			cur.comments, pending = pending, nil
+		}
+		cur.text.WriteByte(c)
+		cur.lastLine = line
+	}
+	for i := 0; i < len(ddl); i++ {
+		c := ddl[i]
+		switch {
+		case c == '\n':
+			line++
+			if depth > 0 {
+				cur.text.WriteByte(' ')
+			}
+		case c == '-' && i+1 < len(ddl) && ddl[i+1] == '-':
+			end := strings.IndexByte(ddl[i:], '\n')
+			if end == -1 {
+				end = len(ddl) - i
+			}
+			text := strings.TrimSpace(strings.TrimLeft(ddl[i:i+end], "-"))
+			i += end - 1
+			if text == "" {
+				continue
+			}
+			switch {
+			case depth == 0 || cur.lastLine == -1 && len(defs) == 0 && line == parenLine:
+				tableLines = append(tableLines, text)
+			case cur.lastLine == line:
+				cur.comments = append(cur.comments, text)
+			case cur.lastLine == -1 && len(defs) > 0 && defs[len(defs)-1].lastLine == line:
+				defs[len(defs)-1].comments = append(defs[len(defs)-1].comments, text)
+			default:
+				pending = append(pending, text)
+			}
+		case c == '/' && i+1 < len(ddl) && ddl[i+1] == '*':
+			end := strings.Index(ddl[i+2:], "*/")
+			if end == -1 {
+				end = len(ddl) - i - 2
+			}
+			line += strings.Count(ddl[i:i+2+end], "\n")
+			i += end + 3
+		case c == '\'' || c == '"' || c == '`' || c == '[':
+			closing := c
+			if c == '[' {
+				closing = ']'
+			}
+			end := strings.IndexByte(ddl[i+1:], closing)
+			if end == -1 {
+				end = len(ddl) - i - 1
+			}
+			if depth > 0 {
+				for _, b := range []byte(ddl[i : i+2+end]) {
+					content(b)
+				}
+			}
+			line += strings.Count(ddl[i:i+1+end], "\n")
+			i += end + 1
+		case c == '(':
+			depth++
+			if depth == 1 {
+				parenLine = line
+				continue
+			}
+			content(c)
+		case c == ')':
+			depth--
+			if depth == 0 {
+				defs = append(defs, cur)
+				i = len(ddl)
+				continue
+			}
+			content(c)
+		case c == ',' && depth == 1:
+			defs = append(defs, cur)
+			cur = &ddlDefinition{lastLine: -1}
+		case c == ' ' || c == '\t' || c == '\r':
+			if depth > 0 {
+				cur.text.WriteByte(' ')
+			}
+		default:
+			if depth > 0 {
+				content(c)
+			}
+		}
+	}
+
+	columnComments = map[string]string{}
+	for _, def := range defs {
+		name := firstIdentifier(strings.TrimSpace(def.text.String()))
+		if name == "" || len(def.comments) == 0 {
+			continue
+		}
+		switch strings.ToUpper(name) {
+		case "CONSTRAINT", "PRIMARY", "UNIQUE", "CHECK", "FOREIGN":
+			continue
+		}
+		columnComments[unquoteIdentifier(name)] = strings.Join(def.comments, "\n")
+	}
+	return strings.Join(tableLines, "\n"), columnComments
+}
+
+// firstIdentifier returns the leading, possibly quoted, identifier of a column definition.
+func firstIdentifier(s string) string {
+	if s == "" {
+		return ""
+	}
+	closing := map[byte]byte{'"': '"', '`': '`', '\'': '\'', '[': ']'}[s[0]]
+	if closing == 0 {
+		if end := strings.IndexAny(s, " ("); end != -1 {
+			return s[:end]
+		}
+		return s
+	}
+	for i := 1; i < len(s); i++ {
+		if s[i] == closing {
+			if closing != ']' && i+1 < len(s) && s[i+1] == closing {
+				i++
+				continue
+			}
+			return s[:i+1]
+		}
+	}
+	return s
+}
+
+func unquoteIdentifier(s string) string {
+	if len(s) >= 2 {
+		switch s[0] {
+		case '"', '`', '\'':
+			if s[len(s)-1] == s[0] {
+				return strings.ReplaceAll(s[1:len(s)-1], string(s[0])+string(s[0]), string(s[0]))
+			}
+		case '[':
+			if s[len(s)-1] == ']' {
+				return s[1 : len(s)-1]
+			}
+		}
+	}
+	return s
+}
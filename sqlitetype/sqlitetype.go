@@ -19,7 +19,10 @@ var TypeMap = map[string]func(gorm.ColumnType) string{
 	},
 	"TINYINT": func(ct gorm.ColumnType) string {
 		// Treat TINYINT(1) as bool; otherwise int8
-		col, _ := ct.ColumnType()
+		col, ok := ct.ColumnType()
+		if !ok {
+			col = ct.DatabaseTypeName()
+		}
 		n, _ := ct.Nullable()
 		if strings.HasPrefix(strings.ToUpper(col), "TINYINT(1)") {
 			return nullablePtr(n, "bool")
@@ -78,17 +81,34 @@ var TypeMap = map[string]func(gorm.ColumnType) string{
 	},
 
 	// ---- decimals / numerics ----
-	// If you need exact decimals, map to shopspring/decimal and import it.
 	"NUMERIC": func(ct gorm.ColumnType) string {
 		n, _ := ct.Nullable()
-		return nullablePtr(n, "float64")
+		return nullablePtr(n, "pgtypes.Decimal")
 	},
 	"DECIMAL": func(ct gorm.ColumnType) string {
 		n, _ := ct.Nullable()
-		return nullablePtr(n, "float64")
+		return nullablePtr(n, "pgtypes.Decimal")
 	},
 }
 
+// AddSizedTypes maps the declared types of columns that carry a size, such as VARCHAR(255) or
+// NUMERIC(12,2), to the mapping of their base type. The driver reports declared types verbatim,
+// so without an entry of their own these columns would miss the type map.
+func AddSizedTypes(typeMap map[string]func(gorm.ColumnType) string, columns []Column) {
+	for _, c := range columns {
+		base, _, sized := strings.Cut(c.Type, "(")
+		if _, ok := typeMap[c.Type]; ok || !sized {
+			continue
+		}
+		base = strings.TrimSpace(base)
+		if mapping, ok := typeMap[base]; ok {
+			typeMap[c.Type] = mapping
+		} else if mapping, ok := typeMap[strings.ToUpper(base)]; ok {
+			typeMap[c.Type] = mapping
+		}
+	}
+}
+
 // TableNames returns user-defined (non-internal) tables for SQLite.
 func TableNames(db *gorm.DB) (tableNames []string) {
 	tableNames = []string{}
@@ -103,9 +123,92 @@ func nullablePtr(yes bool, base string) string {
 	if yes {
 		// make a pointer for nullable scalar types
 		switch base {
-		case "bool", "int", "int8", "int16", "int32", "int64", "uint64", "float32", "float64", "string", "time.Time", "time.Duration":
+		case "bool", "int", "int8", "int16", "int32", "int64", "uint64", "float32", "float64", "string", "time.Time", "time.Duration", "pgtypes.Decimal":
 			return "*" + base
 		}
 	}
 	return base
 }
+
+// ForeignKey is a foreign key constraint declared on a SQLite table.
+type ForeignKey struct {
+	ID         int
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+}
+
+// ForeignKeys returns the foreign keys declared on table, with the columns of composite keys in
+// declaration order. A key that omits its referenced columns points at the parent's primary key.
+func ForeignKeys(db *gorm.DB, table string) []ForeignKey {
+	rows := []struct {
+		ID    int
+		Seq   int
+		Table string
+		From  string
+		To    *string
+	}{}
+	err := db.Raw(`SELECT "id", "seq", "table", "from", "to" FROM pragma_foreign_key_list(?) ORDER BY "id", "seq"`, table).Scan(&rows).Error
+	if err != nil {
+		panic(err)
+	}
+	fks := []ForeignKey{}
+	for _, r := range rows {
+		if len(fks) == 0 || fks[len(fks)-1].ID != r.ID {
+			fks = append(fks, ForeignKey{ID: r.ID, RefTable: r.Table})
+		}
+		fk := &fks[len(fks)-1]
+		fk.Columns = append(fk.Columns, r.From)
+		if r.To != nil {
+			fk.RefColumns = append(fk.RefColumns, *r.To)
+		}
+	}
+	for i := range fks {
+		if len(fks[i].RefColumns) == 0 {
+			fks[i].RefColumns = primaryKey(db, fks[i].RefTable)
+		}
+	}
+	return fks
+}
+
+// UniqueKeys returns the column sets that are unique in table: the primary key and every
+// non-partial unique index (including those backing UNIQUE constraints).
+func UniqueKeys(db *gorm.DB, table string) [][]string {
+	keys := [][]string{}
+	if pk := primaryKey(db, table); len(pk) > 0 {
+		keys = append(keys, pk)
+	}
+	indexes := []string{}
+	err := db.Raw(`SELECT name FROM pragma_index_list(?) WHERE "unique" = 1 AND "partial" = 0 AND origin <> 'pk'`, table).Scan(&indexes).Error
+	if err != nil {
+		panic(err)
+	}
+	for _, index := range indexes {
+		columns := []*string{}
+		err := db.Raw(`SELECT name FROM pragma_index_info(?) ORDER BY seqno`, index).Scan(&columns).Error
+		if err != nil {
+			panic(err)
+		}
+		key := []string{}
+		for _, c := range columns {
+			if c == nil { // expression index
+				key = nil
+				break
+			}
+			key = append(key, *c)
+		}
+		if len(key) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func primaryKey(db *gorm.DB, table string) []string {
+	columns := []string{}
+	err := db.Raw(`SELECT name FROM pragma_table_info(?) WHERE pk > 0 ORDER BY pk`, table).Scan(&columns).Error
+	if err != nil {
+		panic(err)
+	}
+	return columns
+}
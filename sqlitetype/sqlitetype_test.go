@@ -105,3 +105,33 @@ func TestTypeMap_IntegerAndTextAndDate(t *testing.T) {
 
 // Ensure the package compiles references for gorm.DB in signatures (unused import fix)
 var _ = gorm.DB{}
+
+func TestParseDDLComments(t *testing.T) {
+	ddl := `CREATE TABLE "user accounts" -- Registered users
+( -- one row per login
+    id INTEGER PRIMARY KEY, -- surrogate key
+    -- Login e-mail address.
+    -- Unique, compared case-insensitively.
+    email TEXT NOT NULL CHECK (email LIKE '%@%'),
+    "display name" TEXT DEFAULT 'it''s -- me', -- shown in the UI
+    [age] INTEGER /* years -- not a comment */,
+    created_at DATETIME
+        DEFAULT CURRENT_TIMESTAMP -- set on insert
+    ,
+    -- table constraint comments are ignored
+    UNIQUE (email)
+)`
+	table, columns := parseDDLComments(ddl)
+	if table != "Registered users\none row per login" {
+		t.Errorf("table comment %q", table)
+	}
+	want := map[string]string{
+		"id":           "surrogate key",
+		"email":        "Login e-mail address.\nUnique, compared case-insensitively.",
+		"display name": "shown in the UI",
+		"created_at":   "set on insert",
+	}
+	if !reflect.DeepEqual(columns, want) {
+		t.Errorf("column comments %#v", columns)
+	}
+}
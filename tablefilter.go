@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// tableFilter decides which tables become models based on the IncludeTables and
+// ExcludeTables patterns. A pattern wrapped in slashes (e.g. /^audit_.*$/) is a regular
+// expression; anything else is a glob as understood by path.Match (e.g. goose_*).
+type tableFilter struct {
+	include []tablePattern
+	exclude []tablePattern
+}
+
+type tablePattern struct {
+	glob string
+	re   *regexp.Regexp
+}
+
+func newTableFilter(include, exclude []string) (*tableFilter, error) {
+	f := &tableFilter{}
+	var err error
+	if f.include, err = compileTablePatterns(include); err != nil {
+		return nil, fmt.Errorf("IncludeTables: %w", err)
+	}
+	if f.exclude, err = compileTablePatterns(exclude); err != nil {
+		return nil, fmt.Errorf("ExcludeTables: %w", err)
+	}
+	return f, nil
+}
+
+func compileTablePatterns(patterns []string) ([]tablePattern, error) {
+	out := make([]tablePattern, 0, len(patterns))
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if len(p) > 1 && strings.HasPrefix(p, "/") && strings.HasSuffix(p, "/") {
+			re, err := regexp.Compile(p[1 : len(p)-1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid table regex %s: %w", p, err)
+			}
+			out = append(out, tablePattern{re: re})
+			continue
+		}
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid table glob %q: %w", p, err)
+		}
+		out = append(out, tablePattern{glob: p})
+	}
+	return out, nil
+}
+
+func (p tablePattern) match(name string) bool {
+	if p.re != nil {
+		return p.re.MatchString(name)
+	}
+	ok, _ := path.Match(p.glob, name)
+	return ok
+}
+
+// Allows reports whether a table should be generated. names holds every name the table can be
+// referred to by (for PostgreSQL both "billing.invoices" and "invoices"); a pattern applies when it
+// matches any of them. With no include patterns every table is included; exclusions always win.
+func (f *tableFilter) Allows(names ...string) bool {
+	if len(f.include) > 0 && !matchAnyTablePattern(f.include, names) {
+		return false
+	}
+	return !matchAnyTablePattern(f.exclude, names)
+}
+
+func matchAnyTablePattern(patterns []tablePattern, names []string) bool {
+	for _, p := range patterns {
+		for _, n := range names {
+			if p.match(n) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitTablePatterns splits the value of the --tables flag into individual patterns. Commas
+// inside a /regex/ pattern, such as /^a{1,3}$/, do not split it.
+func splitTablePatterns(s string) []string {
+	patterns := []string{}
+	add := func(p string) {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	start, inRegex := 0, false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && inRegex:
+			i++
+		case c == '/' && (inRegex || strings.TrimSpace(s[start:i]) == ""):
+			inRegex = !inRegex
+		case c == ',' && !inRegex:
+			add(s[start:i])
+			start = i + 1
+		}
+	}
+	add(s[start:])
+	return patterns
+}
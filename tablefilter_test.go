@@ -0,0 +1,54 @@
+package main
+
+import "testing"
+
+func TestTableFilter(t *testing.T) {
+	f, err := newTableFilter(nil, []string{"schema_migrations", "goose_*", "/^spatial_ref_sys$/"})
+	if err != nil {
+		t.Fatalf("newTableFilter: %v", err)
+	}
+	for _, name := range []string{"schema_migrations", "goose_db_version", "spatial_ref_sys"} {
+		if f.Allows(name) {
+			t.Fatalf("expected %s to be excluded", name)
+		}
+	}
+	if !f.Allows("users") {
+		t.Fatalf("expected users to be included")
+	}
+
+	f, err = newTableFilter([]string{"billing.*", "/^audit_log$/"}, []string{"billing.tmp_*"})
+	if err != nil {
+		t.Fatalf("newTableFilter: %v", err)
+	}
+	if !f.Allows("billing.invoices", "invoices") {
+		t.Fatalf("expected billing.invoices to be included")
+	}
+	if !f.Allows("audit.audit_log", "audit_log") {
+		t.Fatalf("expected bare-name regex match for audit.audit_log")
+	}
+	if f.Allows("billing.tmp_import", "tmp_import") {
+		t.Fatalf("exclusions must win over inclusions")
+	}
+	if f.Allows("users") {
+		t.Fatalf("expected users to be filtered out by IncludeTables")
+	}
+
+	if _, err := newTableFilter([]string{"/([/"}, nil); err == nil {
+		t.Fatalf("expected invalid regex to be rejected")
+	}
+	if _, err := newTableFilter(nil, []string{"[a-"}); err == nil {
+		t.Fatalf("expected invalid glob to be rejected")
+	}
+}
+
+func TestSplitTablePatterns(t *testing.T) {
+	got := splitTablePatterns(" orders, order_items ,,")
+	if len(got) != 2 || got[0] != "orders" || got[1] != "order_items" {
+		t.Fatalf("unexpected patterns: %q", got)
+	}
+
+	got = splitTablePatterns("/^a{1,3}$/, orders,/^b\\/c{2,}$/")
+	if len(got) != 3 || got[0] != "/^a{1,3}$/" || got[1] != "orders" || got[2] != "/^b\\/c{2,}$/" {
+		t.Fatalf("unexpected patterns: %q", got)
+	}
+}